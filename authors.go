@@ -0,0 +1,119 @@
+package gutendex
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+)
+
+// Author aggregates the books written by one person. Variant spellings of
+// the name across books are merged as described for PersonMatcher, and the
+// embedded Person holds the most complete spelling.
+type Author struct {
+	Person
+	// Books lists the author's books in listing order.
+	Books []Book
+	// Languages lists the distinct languages of Books, sorted.
+	Languages []string
+	// DownloadCount is the total download count of Books.
+	DownloadCount int
+}
+
+// Authors lists the authors of the books matching q, most downloaded
+// first. Gutendex has no authors endpoint, so this walks the whole listing
+// for q; narrow the query where possible.
+func (c *Client) Authors(ctx context.Context, q Query) ([]Author, error) {
+	return listAuthors(ctx, c, q)
+}
+
+// AuthorBooks returns the books by the author with the given name, matched
+// as by SamePerson. A non-empty years restricts the result to the author
+// whose Person.LifeSpan equals it, to tell apart namesakes. Books are
+// looked up by family name, so spellings that differ in the family name
+// are only found if the listing returns them.
+func (c *Client) AuthorBooks(ctx context.Context, name, years string) ([]Book, error) {
+	return authorBooks(ctx, c, name, years)
+}
+
+// Authors lists the authors of the catalog books matching q, most
+// downloaded first.
+func (c *Catalog) Authors(ctx context.Context, q Query) ([]Author, error) {
+	return listAuthors(ctx, c, q)
+}
+
+// AuthorBooks returns the catalog books by the author with the given name,
+// as for Client.AuthorBooks.
+func (c *Catalog) AuthorBooks(ctx context.Context, name, years string) ([]Book, error) {
+	return authorBooks(ctx, c, name, years)
+}
+
+func listAuthors(ctx context.Context, src BookSource, q Query) ([]Author, error) {
+	var m PersonMatcher
+	var order []*Author
+	byGroup := make(map[*personGroup]*Author)
+	it := src.ListBooks(q)
+	it.ctx = ctx
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		b := it.Value()
+		for _, p := range b.Authors {
+			g := m.add(p)
+			a, ok := byGroup[g]
+			if !ok {
+				a = &Author{}
+				byGroup[g] = a
+				order = append(order, a)
+			}
+			a.Person = g.best
+			// A book crediting two spellings of one author counts once.
+			if n := len(a.Books); n == 0 || a.Books[n-1].ID != b.ID {
+				a.Books = append(a.Books, b)
+				a.DownloadCount += b.DownloadCount
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	out := make([]Author, len(order))
+	for i, a := range order {
+		for _, b := range a.Books {
+			a.Languages = append(a.Languages, b.Languages...)
+		}
+		slices.Sort(a.Languages)
+		a.Languages = slices.Compact(a.Languages)
+		out[i] = *a
+	}
+	slices.SortStableFunc(out, func(a, b Author) int {
+		if c := cmp.Compare(b.DownloadCount, a.DownloadCount); c != 0 {
+			return c
+		}
+		return strings.Compare(strings.ToLower(a.SortName()), strings.ToLower(b.SortName()))
+	})
+	return out, nil
+}
+
+func authorBooks(ctx context.Context, src BookSource, name, years string) ([]Book, error) {
+	want := Person{Name: name}
+	// Query by family name only, so that variant spellings of the given
+	// names are still listed.
+	authors, err := listAuthors(ctx, src, Query{Author: want.FamilyName()})
+	if err != nil {
+		return nil, err
+	}
+	var books []Book
+	for _, a := range authors {
+		if !SamePerson(a.Person, want) || years != "" && a.LifeSpan() != years {
+			continue
+		}
+		for _, b := range a.Books {
+			if !slices.ContainsFunc(books, func(x Book) bool { return x.ID == b.ID }) {
+				books = append(books, b)
+			}
+		}
+	}
+	return books, nil
+}
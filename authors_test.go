@@ -0,0 +1,100 @@
+package gutendex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func authorBooksFixture() []Book {
+	return []Book{
+		{ID: 1, Title: "Pride and Prejudice", Authors: []Person{{Name: "Austen, Jane", BirthYear: year(1775), DeathYear: year(1817)}}, Languages: []string{"en"}, DownloadCount: 50},
+		{ID: 2, Title: "Emma", Authors: []Person{{Name: "Austen, Jane"}}, Languages: []string{"en"}, DownloadCount: 80},
+		{ID: 3, Title: "Orgueil et préjugés", Authors: []Person{{Name: "Austen, Jane"}}, Languages: []string{"fr"}, DownloadCount: 5},
+		{ID: 4, Title: "The Three Musketeers", Authors: []Person{{Name: "Dumas, Alexandre", BirthYear: year(1802), DeathYear: year(1870)}}, Languages: []string{"en"}, DownloadCount: 90},
+		{ID: 5, Title: "Camille", Authors: []Person{{Name: "Dumas, Alexandre", BirthYear: year(1824), DeathYear: year(1895)}}, Languages: []string{"en"}, DownloadCount: 10},
+		{ID: 6, Title: "Crime and Punishment", Authors: []Person{{Name: "Dostoyevsky, Fyodor"}}, Languages: []string{"en"}, DownloadCount: 40},
+		{ID: 7, Title: "The Idiot", Authors: []Person{{Name: "Dostoevsky, Fyodor", BirthYear: year(1821), DeathYear: year(1881)}}, Languages: []string{"en"}, DownloadCount: 30},
+		{ID: 8, Title: "Orthodoxy", Authors: []Person{{Name: "Chesterton, G. K. (Gilbert Keith)"}}, Languages: []string{"en"}, DownloadCount: 1},
+		{ID: 9, Title: "Heretics", Authors: []Person{{Name: "Chesterton, Gilbert Keith"}}, Languages: []string{"en"}, DownloadCount: 1},
+	}
+}
+
+func TestCatalogAuthors(t *testing.T) {
+	c := NewCatalog(authorBooksFixture()...)
+	authors, err := c.Authors(context.Background(), Query{})
+	if err != nil {
+		t.Fatalf("Authors: %v", err)
+	}
+	type summary struct {
+		name, years string
+		books       int
+		langs       []string
+		downloads   int
+	}
+	var got []summary
+	for _, a := range authors {
+		got = append(got, summary{a.Name, a.LifeSpan(), len(a.Books), a.Languages, a.DownloadCount})
+	}
+	want := []summary{
+		{"Austen, Jane", "1775-1817", 3, []string{"en", "fr"}, 135},
+		{"Dumas, Alexandre", "1802-1870", 1, []string{"en"}, 90},
+		{"Dostoevsky, Fyodor", "1821-1881", 2, []string{"en"}, 70},
+		{"Dumas, Alexandre", "1824-1895", 1, []string{"en"}, 10},
+		{"Chesterton, G. K. (Gilbert Keith)", "", 2, []string{"en"}, 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestCatalogAuthorBooks(t *testing.T) {
+	c := NewCatalog(authorBooksFixture()...)
+	tests := []struct {
+		name, years string
+		want        []int
+	}{
+		{"Chesterton, G. K.", "", []int{8, 9}},
+		{"Dumas, Alexandre", "1824-1895", []int{5}},
+		{"Dumas, Alexandre", "", []int{4, 5}},
+		{"Wilde, Oscar", "", nil},
+	}
+	for _, tt := range tests {
+		books, err := c.AuthorBooks(context.Background(), tt.name, tt.years)
+		if err != nil {
+			t.Fatalf("AuthorBooks(%q): %v", tt.name, err)
+		}
+		var ids []int
+		for _, b := range books {
+			ids = append(ids, b.ID)
+		}
+		if !slices.Equal(ids, tt.want) {
+			t.Errorf("AuthorBooks(%q, %q) = %v, want %v", tt.name, tt.years, ids, tt.want)
+		}
+	}
+}
+
+func TestClientAuthorBooks(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		books := authorBooksFixture()[:3]
+		_ = json.NewEncoder(w).Encode(Page[Book]{Count: len(books), Results: books})
+	}))
+	defer srv.Close()
+
+	books, err := newTestClient(srv.URL).AuthorBooks(context.Background(), "Austen, Jane", "1775-1817")
+	if err != nil {
+		t.Fatalf("AuthorBooks: %v", err)
+	}
+	if len(books) != 3 {
+		t.Fatalf("got %d books", len(books))
+	}
+	if len(queries) != 1 || queries[0] != "author=Austen" {
+		t.Fatalf("queries = %q", queries)
+	}
+}
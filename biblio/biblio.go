@@ -0,0 +1,86 @@
+// Package biblio converts books into bibliographic formats for citation
+// managers and the web: BibTeX, RIS, CSL-JSON, Dublin Core XML and
+// schema.org JSON-LD.
+package biblio
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+	"github.com/alex-rs/go-gutendex/internal"
+)
+
+// Publisher is the publisher recorded for every book.
+const Publisher = "Project Gutenberg"
+
+// EbookURL returns the canonical Project Gutenberg page of b.
+func EbookURL(b gutendex.Book) string {
+	return "https://www.gutenberg.org/ebooks/" + strconv.Itoa(b.ID)
+}
+
+// CitationKey derives a citation key from the first author's surname and
+// the first significant word of the title, e.g. "austen_pride". Books
+// without authors use "anon"; the ebook number is used when the title has
+// no usable word.
+func CitationKey(b gutendex.Book) string {
+	author := "anon"
+	if len(b.Authors) > 0 {
+		if s := keyPart(b.Authors[0].FamilyName()); s != "" {
+			author = s
+		}
+	}
+	word := strconv.Itoa(b.ID)
+	for _, w := range strings.Fields(b.Title) {
+		if k := keyPart(w); k != "" && !stopWords[k] {
+			word = k
+			break
+		}
+	}
+	return author + "_" + word
+}
+
+var stopWords = map[string]bool{"a": true, "an": true, "the": true, "of": true, "on": true, "and": true}
+
+// keyPart lowercases s and keeps ASCII letters and digits, folding common
+// accented letters to their base letter.
+func keyPart(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if f, ok := internal.FoldRune(r); ok {
+			sb.WriteString(f)
+		} else if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// name is a person's name split into the parts citation formats use. The
+// suffix carries both generational suffixes and titles, e.g. "Jr." or
+// "graf", since none of the formats distinguish them.
+type name struct {
+	family, given, particle, suffix string
+}
+
+func splitName(p gutendex.Person) name {
+	parts := p.NameParts()
+	suffix := parts.Suffix
+	if parts.Honorific != "" {
+		suffix = strings.TrimPrefix(suffix+", "+parts.Honorific, ", ")
+	}
+	return name{family: parts.Family, given: parts.Given, particle: parts.Particle, suffix: suffix}
+}
+
+// keyer hands out citation keys that are unique within one export.
+type keyer map[string]int
+
+func (k keyer) key(b gutendex.Book) string {
+	base := CitationKey(b)
+	k[base]++
+	if n := k[base]; n > 1 {
+		return base + "_" + strconv.Itoa(n)
+	}
+	return base
+}
@@ -0,0 +1,183 @@
+package biblio
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+var (
+	pride = gutendex.Book{
+		ID:        1342,
+		Title:     "Pride and Prejudice",
+		Authors:   []gutendex.Person{{Name: "Austen, Jane"}},
+		Subjects:  []string{"Courtship -- Fiction", "England -- Fiction"},
+		Languages: []string{"en"},
+		Copyright: boolPtr(false),
+		MediaType: "Text",
+		Formats:   map[string]string{"text/html": "h", "application/epub+zip": "e"},
+	}
+	war = gutendex.Book{
+		ID:          2600,
+		Title:       "War & Peace",
+		Authors:     []gutendex.Person{{Name: "Tolstoy, Leo, graf"}},
+		Translators: []gutendex.Person{{Name: "Maude, Louise"}, {Name: "Maude, Aylmer"}},
+		Languages:   []string{"en"},
+	}
+	iliad = gutendex.Book{ID: 6130, Title: "The Iliad", Authors: []gutendex.Person{{Name: "Homer"}}}
+)
+
+func TestCitationKey(t *testing.T) {
+	tests := []struct {
+		b    gutendex.Book
+		want string
+	}{
+		{pride, "austen_pride"},
+		{war, "tolstoy_war"},
+		{iliad, "homer_iliad"},
+		{gutendex.Book{ID: 5, Title: "Émile", Authors: []gutendex.Person{{Name: "Rousseau, Jean-Jacques"}}}, "rousseau_emile"},
+		{gutendex.Book{ID: 7, Title: "The"}, "anon_7"},
+	}
+	for _, tt := range tests {
+		if got := CitationKey(tt.b); got != tt.want {
+			t.Errorf("CitationKey(%q) = %q, want %q", tt.b.Title, got, tt.want)
+		}
+	}
+}
+
+func TestBibTeX(t *testing.T) {
+	want := "@book{tolstoy_war,\n" +
+		"  author = {Tolstoy, graf, Leo},\n" +
+		"  translator = {Maude, Louise and Maude, Aylmer},\n" +
+		"  title = {{War \\& Peace}},\n" +
+		"  publisher = {Project Gutenberg},\n" +
+		"  url = {https://www.gutenberg.org/ebooks/2600},\n" +
+		"  language = {en},\n" +
+		"  note = {Project Gutenberg EBook \\#2600},\n" +
+		"}\n"
+	if got := BibTeX(war); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if got := BibTeX(iliad); !strings.Contains(got, "author = {{Homer}},") {
+		t.Fatalf("single-word names must be braced:\n%s", got)
+	}
+}
+
+func TestWriteBibTeXUniqueKeys(t *testing.T) {
+	emma := gutendex.Book{ID: 158, Title: "Pride Revisited", Authors: pride.Authors}
+	var buf bytes.Buffer
+	c := gutendex.NewCatalog(pride, emma, iliad)
+	if err := WriteBibTeX(&buf, c.ListBooks(gutendex.Query{Sort: gutendex.SortAscending})); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"@book{austen_pride,", "@book{austen_pride_2,", "@book{homer_iliad,"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "@book{") != 3 {
+		t.Fatalf("expected 3 entries:\n%s", out)
+	}
+}
+
+func TestRIS(t *testing.T) {
+	want := "TY  - BOOK\r\nID  - tolstoy_war\r\nTI  - War & Peace\r\nAU  - Tolstoy, Leo, graf\r\n" +
+		"A4  - Maude, Louise\r\nA4  - Maude, Aylmer\r\nPB  - Project Gutenberg\r\n" +
+		"UR  - https://www.gutenberg.org/ebooks/2600\r\nAN  - 2600\r\nLA  - en\r\nER  - \r\n"
+	if got := RIS(war); got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestCSL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSL(&buf, gutendex.NewCatalog(war, iliad).ListBooks(gutendex.Query{Sort: gutendex.SortDescending})); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var items []CSLItem
+	if err := json.Unmarshal(buf.Bytes(), &items); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items", len(items))
+	}
+	homer, tolstoy := items[0], items[1]
+	if homer.Author[0] != (CSLName{Literal: "Homer"}) || homer.Type != "book" {
+		t.Fatalf("unexpected item: %+v", homer)
+	}
+	if tolstoy.Author[0] != (CSLName{Family: "Tolstoy", Given: "Leo", Suffix: "graf"}) ||
+		len(tolstoy.Translator) != 2 || tolstoy.URL != "https://www.gutenberg.org/ebooks/2600" || tolstoy.Number != "2600" {
+		t.Fatalf("unexpected item: %+v", tolstoy)
+	}
+}
+
+func TestWriteDublinCore(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDublinCore(&buf, pride); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/">`,
+		`<dc:identifier>https://www.gutenberg.org/ebooks/1342</dc:identifier>`,
+		`<dc:creator>Austen, Jane</dc:creator>`,
+		`<dc:subject>England -- Fiction</dc:subject>`,
+		`<dc:format>application/epub+zip</dc:format>`,
+		`<dc:rights>Public domain in the USA.</dc:rights>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+	var v struct{}
+	if err := xml.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("not well-formed: %v", err)
+	}
+}
+
+func TestJSONLD(t *testing.T) {
+	born, died := 1828, 1910
+	b := war
+	b.Authors = []gutendex.Person{{Name: "Tolstoy, Leo, graf", BirthYear: &born, DeathYear: &died}}
+	b.Bookshelves = []string{"Best Books Ever Listings"}
+	b.Copyright = boolPtr(false)
+	b.DownloadCount = 4210
+	b.Formats = map[string]string{"text/html": "h", "image/jpeg": "cover.jpg", "application/epub+zip": "e"}
+
+	var buf bytes.Buffer
+	if err := WriteJSONLD(&buf, b); err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["@context"] != SchemaContext || raw["@type"] != "Book" || raw["image"] != "cover.jpg" {
+		t.Fatalf("unexpected document:\n%s", buf.String())
+	}
+	author := raw["author"].([]any)[0].(map[string]any)
+	if author["name"] != "Leo Tolstoy" || author["honorificSuffix"] != "graf" || author["birthDate"] != "1828" {
+		t.Fatalf("author = %v", author)
+	}
+
+	var s SchemaBook
+	if err := json.Unmarshal(buf.Bytes(), &s); err != nil {
+		t.Fatal(err)
+	}
+	got := s.Book()
+	b.MediaType = ""
+	if !reflect.DeepEqual(got, b) {
+		t.Fatalf("round trip:\n got %+v\nwant %+v", got, b)
+	}
+	if got := JSONLD(iliad).Author[0]; got.Name != "Homer" || got.FamilyName != "" {
+		t.Fatalf("single-word author = %+v", got)
+	}
+}
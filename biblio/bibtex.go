@@ -0,0 +1,80 @@
+package biblio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// BibTeX renders b as a BibTeX @book entry.
+func BibTeX(b gutendex.Book) string {
+	return bibtex(b, CitationKey(b))
+}
+
+// WriteBibTeX writes every book produced by it as one .bib file, making
+// citation keys unique by suffixing repeats with _2, _3 and so on.
+func WriteBibTeX(w io.Writer, it *gutendex.Iter[gutendex.Book]) error {
+	keys := keyer{}
+	first := true
+	for it.Next() {
+		b := it.Value()
+		sep := "\n"
+		if first {
+			sep, first = "", false
+		}
+		if _, err := io.WriteString(w, sep+bibtex(b, keys.key(b))); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+func bibtex(b gutendex.Book, key string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "@book{%s,\n", key)
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "  %s = {%s},\n", name, value)
+		}
+	}
+	field("author", bibNames(b.Authors))
+	field("translator", bibNames(b.Translators))
+	field("title", "{"+bibEscape(b.Title)+"}")
+	field("publisher", Publisher)
+	field("url", EbookURL(b))
+	field("language", strings.Join(b.Languages, ", "))
+	field("keywords", bibEscape(strings.Join(b.Subjects, ", ")))
+	field("note", fmt.Sprintf("Project Gutenberg EBook \\#%d", b.ID))
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// bibNames joins people with "and", keeping Gutenberg's "Family, Given"
+// order which BibTeX understands.
+func bibNames(people []gutendex.Person) string {
+	names := make([]string, len(people))
+	for i, p := range people {
+		n := splitName(p)
+		family := strings.TrimSpace(n.particle + " " + n.family)
+		switch {
+		case n.given == "":
+			names[i] = "{" + bibEscape(family) + "}"
+		case n.suffix != "":
+			names[i] = bibEscape(family + ", " + n.suffix + ", " + n.given)
+		default:
+			names[i] = bibEscape(family + ", " + n.given)
+		}
+	}
+	return strings.Join(names, " and ")
+}
+
+var bibEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	"{", `\{`, "}", `\}`,
+	"&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+	"~", `\textasciitilde{}`, "^", `\textasciicircum{}`,
+)
+
+func bibEscape(s string) string { return bibEscaper.Replace(s) }
@@ -0,0 +1,87 @@
+package biblio
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// CSLItem is a CSL-JSON item, the format read by Zotero, Pandoc and
+// citeproc processors.
+type CSLItem struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Title      string    `json:"title"`
+	Author     []CSLName `json:"author,omitempty"`
+	Translator []CSLName `json:"translator,omitempty"`
+	Publisher  string    `json:"publisher"`
+	URL        string    `json:"URL"`
+	Language   string    `json:"language,omitempty"`
+	Keyword    string    `json:"keyword,omitempty"`
+	Number     string    `json:"number,omitempty"`
+}
+
+// CSLName is a CSL-JSON name. Names that cannot be split, such as
+// "Homer", are given as a literal.
+type CSLName struct {
+	Family   string `json:"family,omitempty"`
+	Given    string `json:"given,omitempty"`
+	Particle string `json:"dropping-particle,omitempty"`
+	Suffix   string `json:"suffix,omitempty"`
+	Literal  string `json:"literal,omitempty"`
+}
+
+// CSL converts b into a CSL-JSON item.
+func CSL(b gutendex.Book) CSLItem {
+	return cslItem(b, CitationKey(b))
+}
+
+// WriteCSL writes every book produced by it as a CSL-JSON array.
+func WriteCSL(w io.Writer, it *gutendex.Iter[gutendex.Book]) error {
+	keys := keyer{}
+	items := []CSLItem{}
+	for it.Next() {
+		b := it.Value()
+		items = append(items, cslItem(b, keys.key(b)))
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(items)
+}
+
+func cslItem(b gutendex.Book, key string) CSLItem {
+	item := CSLItem{
+		ID:        key,
+		Type:      "book",
+		Title:     b.Title,
+		Publisher: Publisher,
+		URL:       EbookURL(b),
+		Keyword:   strings.Join(b.Subjects, ", "),
+		Number:    strconv.Itoa(b.ID),
+	}
+	if len(b.Languages) > 0 {
+		item.Language = b.Languages[0]
+	}
+	item.Author = cslNames(b.Authors)
+	item.Translator = cslNames(b.Translators)
+	return item
+}
+
+func cslNames(people []gutendex.Person) []CSLName {
+	var out []CSLName
+	for _, p := range people {
+		n := splitName(p)
+		if n.given == "" {
+			out = append(out, CSLName{Literal: p.Name})
+			continue
+		}
+		out = append(out, CSLName{Family: n.family, Given: n.given, Particle: n.particle, Suffix: n.suffix})
+	}
+	return out
+}
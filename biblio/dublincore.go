@@ -0,0 +1,66 @@
+package biblio
+
+import (
+	"encoding/xml"
+	"io"
+	"slices"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+type dublinCore struct {
+	XMLName     xml.Name `xml:"oai_dc:dc"`
+	XmlnsOAI    string   `xml:"xmlns:oai_dc,attr"`
+	XmlnsDC     string   `xml:"xmlns:dc,attr"`
+	Identifier  string   `xml:"dc:identifier"`
+	Title       string   `xml:"dc:title"`
+	Creator     []string `xml:"dc:creator"`
+	Contributor []string `xml:"dc:contributor"`
+	Subject     []string `xml:"dc:subject"`
+	Language    []string `xml:"dc:language"`
+	Publisher   string   `xml:"dc:publisher"`
+	Type        string   `xml:"dc:type,omitempty"`
+	Format      []string `xml:"dc:format"`
+	Rights      string   `xml:"dc:rights,omitempty"`
+}
+
+// WriteDublinCore writes b as an OAI Dublin Core XML record.
+func WriteDublinCore(w io.Writer, b gutendex.Book) error {
+	dc := dublinCore{
+		XmlnsOAI:   "http://www.openarchives.org/OAI/2.0/oai_dc/",
+		XmlnsDC:    "http://purl.org/dc/elements/1.1/",
+		Identifier: EbookURL(b),
+		Title:      b.Title,
+		Subject:    append(append([]string(nil), b.Subjects...), b.Bookshelves...),
+		Language:   b.Languages,
+		Publisher:  Publisher,
+		Type:       b.MediaType,
+	}
+	for _, p := range b.Authors {
+		dc.Creator = append(dc.Creator, p.Name)
+	}
+	for _, p := range b.Translators {
+		dc.Contributor = append(dc.Contributor, p.Name)
+	}
+	for mime := range b.Formats {
+		dc.Format = append(dc.Format, mime)
+	}
+	slices.Sort(dc.Format)
+	if b.Copyright != nil {
+		if *b.Copyright {
+			dc.Rights = "Copyrighted. Read the copyright notice inside this book for details."
+		} else {
+			dc.Rights = "Public domain in the USA."
+		}
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(dc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
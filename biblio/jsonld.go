@@ -0,0 +1,200 @@
+package biblio
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// SchemaContext is the JSON-LD context of schema.org documents.
+const SchemaContext = "https://schema.org"
+
+// SchemaBook is a schema.org Book in JSON-LD form, suitable for embedding in
+// a <script type="application/ld+json"> element.
+type SchemaBook struct {
+	Context              string                 `json:"@context,omitempty"`
+	Type                 string                 `json:"@type"`
+	ID                   string                 `json:"@id"`
+	Identifier           string                 `json:"identifier"`
+	Name                 string                 `json:"name"`
+	URL                  string                 `json:"url"`
+	Author               []SchemaPerson         `json:"author,omitempty"`
+	Translator           []SchemaPerson         `json:"translator,omitempty"`
+	Publisher            SchemaOrganization     `json:"publisher"`
+	InLanguage           []string               `json:"inLanguage,omitempty"`
+	Keywords             []string               `json:"keywords,omitempty"`
+	Genre                []string               `json:"genre,omitempty"`
+	BookFormat           string                 `json:"bookFormat"`
+	IsAccessibleForFree  bool                   `json:"isAccessibleForFree"`
+	CopyrightNotice      string                 `json:"copyrightNotice,omitempty"`
+	Image                string                 `json:"image,omitempty"`
+	Encoding             []SchemaMediaObject    `json:"encoding,omitempty"`
+	InteractionStatistic *SchemaInteractionStat `json:"interactionStatistic,omitempty"`
+}
+
+// SchemaPerson is a schema.org Person. Name holds the display name, e.g.
+// "Jane Austen"; the parts hold the Gutenberg record split into family
+// name, given names with any particle, and suffix.
+type SchemaPerson struct {
+	Type            string `json:"@type"`
+	Name            string `json:"name"`
+	FamilyName      string `json:"familyName,omitempty"`
+	GivenName       string `json:"givenName,omitempty"`
+	HonorificSuffix string `json:"honorificSuffix,omitempty"`
+	BirthDate       string `json:"birthDate,omitempty"`
+	DeathDate       string `json:"deathDate,omitempty"`
+}
+
+// SchemaOrganization is a schema.org Organization.
+type SchemaOrganization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// SchemaMediaObject is one downloadable encoding of a book.
+type SchemaMediaObject struct {
+	Type           string `json:"@type"`
+	ContentURL     string `json:"contentUrl"`
+	EncodingFormat string `json:"encodingFormat"`
+}
+
+// SchemaInteractionStat records the download count of a book.
+type SchemaInteractionStat struct {
+	Type                 string `json:"@type"`
+	InteractionType      string `json:"interactionType"`
+	UserInteractionCount int    `json:"userInteractionCount"`
+}
+
+const (
+	downloadAction = "https://schema.org/DownloadAction"
+	ebookFormat    = "https://schema.org/EBook"
+)
+
+// JSONLD converts b into a schema.org Book.
+func JSONLD(b gutendex.Book) SchemaBook {
+	s := SchemaBook{
+		Context:             SchemaContext,
+		Type:                "Book",
+		ID:                  EbookURL(b),
+		Identifier:          strconv.Itoa(b.ID),
+		Name:                b.Title,
+		URL:                 EbookURL(b),
+		Author:              schemaPeople(b.Authors),
+		Translator:          schemaPeople(b.Translators),
+		Publisher:           SchemaOrganization{Type: "Organization", Name: Publisher, URL: "https://www.gutenberg.org/"},
+		InLanguage:          b.Languages,
+		Keywords:            b.Subjects,
+		Genre:               b.Bookshelves,
+		BookFormat:          ebookFormat,
+		IsAccessibleForFree: true,
+		Image:               b.Formats[gutendex.CoverFormat],
+	}
+	if b.Copyright != nil {
+		s.CopyrightNotice = "Public domain in the USA."
+		if *b.Copyright {
+			s.CopyrightNotice = "Copyrighted."
+		}
+	}
+	mimes := make([]string, 0, len(b.Formats))
+	for m := range b.Formats {
+		if m != gutendex.CoverFormat {
+			mimes = append(mimes, m)
+		}
+	}
+	slices.SortFunc(mimes, cmp.Compare)
+	for _, m := range mimes {
+		s.Encoding = append(s.Encoding, SchemaMediaObject{Type: "MediaObject", ContentURL: b.Formats[m], EncodingFormat: m})
+	}
+	if b.DownloadCount > 0 {
+		s.InteractionStatistic = &SchemaInteractionStat{
+			Type:                 "InteractionCounter",
+			InteractionType:      downloadAction,
+			UserInteractionCount: b.DownloadCount,
+		}
+	}
+	return s
+}
+
+// Book converts s back into a book. The media type is not part of the
+// schema.org record and is left empty.
+func (s SchemaBook) Book() gutendex.Book {
+	b := gutendex.Book{
+		Title:       s.Name,
+		Authors:     bookPeople(s.Author),
+		Translators: bookPeople(s.Translator),
+		Subjects:    s.Keywords,
+		Bookshelves: s.Genre,
+		Languages:   s.InLanguage,
+	}
+	b.ID, _ = strconv.Atoi(s.Identifier)
+	if s.CopyrightNotice != "" {
+		c := s.CopyrightNotice == "Copyrighted."
+		b.Copyright = &c
+	}
+	if len(s.Encoding) > 0 || s.Image != "" {
+		b.Formats = make(map[string]string, len(s.Encoding)+1)
+		for _, e := range s.Encoding {
+			b.Formats[e.EncodingFormat] = e.ContentURL
+		}
+		if s.Image != "" {
+			b.Formats[gutendex.CoverFormat] = s.Image
+		}
+	}
+	if st := s.InteractionStatistic; st != nil && st.InteractionType == downloadAction {
+		b.DownloadCount = st.UserInteractionCount
+	}
+	return b
+}
+
+// WriteJSONLD writes the schema.org record of b as indented JSON.
+func WriteJSONLD(w io.Writer, b gutendex.Book) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(JSONLD(b))
+}
+
+func schemaPeople(people []gutendex.Person) []SchemaPerson {
+	var out []SchemaPerson
+	for _, p := range people {
+		n := splitName(p)
+		sp := SchemaPerson{Type: "Person", Name: p.DisplayName(), HonorificSuffix: n.suffix}
+		if n.given != "" {
+			sp.FamilyName, sp.GivenName = n.family, strings.TrimSpace(n.given+" "+n.particle)
+		}
+		if p.BirthYear != nil {
+			sp.BirthDate = strconv.Itoa(*p.BirthYear)
+		}
+		if p.DeathYear != nil {
+			sp.DeathDate = strconv.Itoa(*p.DeathYear)
+		}
+		out = append(out, sp)
+	}
+	return out
+}
+
+func bookPeople(people []SchemaPerson) []gutendex.Person {
+	var out []gutendex.Person
+	for _, sp := range people {
+		p := gutendex.Person{Name: sp.Name}
+		if sp.FamilyName != "" {
+			p.Name = sp.FamilyName + ", " + sp.GivenName
+		}
+		if sp.HonorificSuffix != "" {
+			p.Name += ", " + sp.HonorificSuffix
+		}
+		if y, err := strconv.Atoi(sp.BirthDate); err == nil {
+			p.BirthYear = &y
+		}
+		if y, err := strconv.Atoi(sp.DeathDate); err == nil {
+			p.DeathYear = &y
+		}
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package biblio
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// RIS renders b as an RIS record.
+func RIS(b gutendex.Book) string {
+	var sb strings.Builder
+	tag := func(t, v string) {
+		if v != "" {
+			sb.WriteString(t + "  - " + v + "\r\n")
+		}
+	}
+	tag("TY", "BOOK")
+	tag("ID", CitationKey(b))
+	tag("TI", b.Title)
+	for _, p := range b.Authors {
+		tag("AU", p.Name)
+	}
+	for _, p := range b.Translators {
+		tag("A4", p.Name)
+	}
+	tag("PB", Publisher)
+	tag("UR", EbookURL(b))
+	tag("AN", strconv.Itoa(b.ID))
+	for _, l := range b.Languages {
+		tag("LA", l)
+	}
+	for _, s := range b.Subjects {
+		tag("KW", s)
+	}
+	sb.WriteString("ER  - \r\n")
+	return sb.String()
+}
+
+// WriteRIS writes every book produced by it as consecutive RIS records.
+func WriteRIS(w io.Writer, it *gutendex.Iter[gutendex.Book]) error {
+	for it.Next() {
+		if _, err := io.WriteString(w, RIS(it.Value())); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
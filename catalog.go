@@ -0,0 +1,161 @@
+package gutendex
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+var _ BookStore = (*Catalog)(nil)
+
+// Catalog is an in-memory BookStore. It answers the same queries as the
+// remote API, which makes it usable as a local index, a test fake or the
+// backing store of a read-through source. Books are copied on the way in
+// and out, so callers may modify the books they pass or receive.
+type Catalog struct {
+	mu    sync.RWMutex
+	books map[int]Book
+}
+
+// NewCatalog constructs a catalog holding books.
+func NewCatalog(books ...Book) *Catalog {
+	c := &Catalog{books: make(map[int]Book, len(books))}
+	for _, b := range books {
+		c.books[b.ID] = b.clone()
+	}
+	return c
+}
+
+// Len reports the number of books in the catalog.
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.books)
+}
+
+// GetBook retrieves a single book by ID.
+func (c *Catalog) GetBook(_ context.Context, id int) (*Book, error) {
+	c.mu.RLock()
+	b, ok := c.books[id]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, &Error{Op: "catalog.GetBook", Kind: ErrNotFound, Err: fmt.Errorf("book %d", id)}
+	}
+	b = b.clone()
+	return &b, nil
+}
+
+// GetBooks retrieves the books with the given IDs in the order of ids,
+// omitting unknown IDs.
+func (c *Catalog) GetBooks(_ context.Context, ids []int) ([]Book, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var out []Book
+	for _, id := range ids {
+		if b, ok := c.books[id]; ok {
+			out = append(out, b.clone())
+		}
+	}
+	return orderByIDs(out, ids), nil
+}
+
+// ListBooks returns an iterator over books matching the query, ordered by
+// popularity unless the query selects another sort order. Queries are
+// validated as by Client.ListBooks.
+func (c *Catalog) ListBooks(q Query) *Iter[Book] {
+	if err := q.Validate(); err != nil {
+		return newErrIter[Book](err)
+	}
+	books := c.Books(q.Match)
+	q.sort(books)
+	return newSliceIter(books)
+}
+
+// Books returns a snapshot of the books accepted by keep, most downloaded
+// first. A nil keep returns every book.
+func (c *Catalog) Books(keep func(Book) bool) []Book {
+	c.mu.RLock()
+	out := make([]Book, 0, len(c.books))
+	for _, b := range c.books {
+		if keep == nil || keep(b) {
+			out = append(out, b.clone())
+		}
+	}
+	c.mu.RUnlock()
+	slices.SortFunc(out, func(a, b Book) int {
+		if c := cmp.Compare(b.DownloadCount, a.DownloadCount); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return out
+}
+
+// PutBooks inserts or replaces books.
+func (c *Catalog) PutBooks(_ context.Context, books ...Book) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, b := range books {
+		c.books[b.ID] = b.clone()
+	}
+	return nil
+}
+
+// DeleteBooks removes the books with the given IDs. Unknown IDs are ignored.
+func (c *Catalog) DeleteBooks(_ context.Context, ids ...int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range ids {
+		delete(c.books, id)
+	}
+	return nil
+}
+
+// BookIDs returns the IDs of all books in ascending order.
+func (c *Catalog) BookIDs(context.Context) ([]int, error) {
+	c.mu.RLock()
+	ids := make([]int, 0, len(c.books))
+	for id := range c.books {
+		ids = append(ids, id)
+	}
+	c.mu.RUnlock()
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// DecodeCatalog reads a catalog snapshot of JSON-encoded books, one per line,
+// as produced by Catalog.Encode.
+func DecodeCatalog(r io.Reader) (*Catalog, error) {
+	c := NewCatalog()
+	dec := json.NewDecoder(r)
+	for {
+		var b Book
+		if err := dec.Decode(&b); err != nil {
+			if errors.Is(err, io.EOF) {
+				return c, nil
+			}
+			return nil, fmt.Errorf("decode catalog: %w", err)
+		}
+		c.books[b.ID] = b
+	}
+}
+
+// Encode writes the catalog as JSON-encoded books, one per line, in ID order.
+func (c *Catalog) Encode(w io.Writer) error {
+	books := c.Books(nil)
+	slices.SortFunc(books, func(a, b Book) int { return cmp.Compare(a.ID, b.ID) })
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, b := range books {
+		if err := enc.Encode(b); err != nil {
+			return fmt.Errorf("encode catalog: %w", err)
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,165 @@
+package gutendex
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func testBooks() []Book {
+	return []Book{
+		{ID: 1, Title: "Pride and Prejudice", Authors: []Person{{Name: "Austen, Jane"}}, Languages: []string{"en"}, Subjects: []string{"Courtship -- Fiction"}, Formats: map[string]string{"text/plain; charset=us-ascii": "u"}, DownloadCount: 50},
+		{ID: 2, Title: "Emma", Authors: []Person{{Name: "Austen, Jane"}}, Languages: []string{"en"}, Bookshelves: []string{"Best Books Ever Listings"}, DownloadCount: 80},
+		{ID: 3, Title: "Les Misérables", Authors: []Person{{Name: "Hugo, Victor"}}, Languages: []string{"fr"}, Formats: map[string]string{"application/epub+zip": "u"}, DownloadCount: 80},
+	}
+}
+
+func collectIDs(t *testing.T, it *Iter[Book]) []int {
+	t.Helper()
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return ids
+}
+
+func TestCatalogGetBook(t *testing.T) {
+	c := NewCatalog(testBooks()...)
+	b, err := c.GetBook(context.Background(), 2)
+	if err != nil || b.Title != "Emma" {
+		t.Fatalf("GetBook = %+v, %v", b, err)
+	}
+	if _, err := c.GetBook(context.Background(), 99); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestCatalogGetBooksOrder(t *testing.T) {
+	c := NewCatalog(testBooks()...)
+	books, err := c.GetBooks(context.Background(), []int{3, 99, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(books) != 2 || books[0].ID != 3 || books[1].ID != 1 {
+		t.Fatalf("unexpected books: %+v", books)
+	}
+}
+
+func TestCatalogListBooks(t *testing.T) {
+	c := NewCatalog(testBooks()...)
+	tests := []struct {
+		name string
+		q    Query
+		want []int
+	}{
+		{"all by popularity", Query{}, []int{2, 3, 1}},
+		{"author", Query{Author: "austen"}, []int{2, 1}},
+		{"author and title search", Query{Author: "Austen", Title: "Emma"}, []int{2}},
+		{"topic matches bookshelves", Query{Topic: "best books"}, []int{2}},
+		{"languages", Query{Language: "fr,de"}, []int{3}},
+		{"mime prefix", Query{MIME: "text/"}, []int{1}},
+		{"descending", Query{Sort: SortDescending}, []int{3, 2, 1}},
+		{"ascending", Query{Author: "austen", Sort: SortAscending}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectIDs(t, c.ListBooks(tt.q))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCatalogPutBooks(t *testing.T) {
+	c := NewCatalog()
+	if err := c.PutBooks(context.Background(), Book{ID: 7, Title: "a"}, Book{ID: 7, Title: "b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", c.Len())
+	}
+	if b, _ := c.GetBook(context.Background(), 7); b.Title != "b" {
+		t.Fatalf("expected replacement, got %q", b.Title)
+	}
+}
+
+func TestCatalogDeleteBooks(t *testing.T) {
+	c := NewCatalog(testBooks()...)
+	if err := c.DeleteBooks(context.Background(), 2, 99); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids, _ := c.BookIDs(context.Background())
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 3 {
+		t.Fatalf("BookIDs = %v", ids)
+	}
+}
+
+func TestCatalogEncodeDecode(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewCatalog(testBooks()...).Encode(&buf); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Fatalf("expected 3 lines, got %d", lines)
+	}
+	c, err := DecodeCatalog(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if b, err := c.GetBook(context.Background(), 3); err != nil || b.Title != "Les Misérables" {
+		t.Fatalf("GetBook = %+v, %v", b, err)
+	}
+	if _, err := DecodeCatalog(strings.NewReader("{bad")); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
+
+func TestCatalogCopiesBooks(t *testing.T) {
+	ctx := context.Background()
+	in := testBooks()
+	c := NewCatalog(in...)
+	in[0].Authors[0].Name = "changed by caller"
+
+	b, err := c.GetBook(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Subjects[0] = "changed"
+	b.Formats["text/plain; charset=us-ascii"] = "changed"
+	books, err := c.GetBooks(ctx, []int{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	books[0].Languages[0] = "changed"
+	listed := collectBooks(t, c.ListBooks(Query{}))
+	listed[0].Authors[0].Name = "changed"
+
+	got, err := c.GetBook(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := testBooks()[0]
+	if got.Authors[0].Name != want.Authors[0].Name || got.Subjects[0] != want.Subjects[0] ||
+		got.Languages[0] != want.Languages[0] || got.Formats["text/plain; charset=us-ascii"] != "u" {
+		t.Errorf("catalog changed through a caller's book: %+v", got)
+	}
+}
+
+func collectBooks(t *testing.T, it *Iter[Book]) []Book {
+	t.Helper()
+	books, err := it.Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return books
+}
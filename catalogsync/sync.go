@@ -0,0 +1,237 @@
+// Package catalogsync keeps a local catalog up to date with a remote book
+// source without re-downloading the whole catalog.
+//
+// An incremental sync walks the remote listing in descending ID order and
+// stops once it reaches books older than the last recorded watermark. A full
+// sync walks the entire listing and also detects removed books. Both upsert
+// changed books into the store and report the affected IDs as a change log
+// for downstream consumers.
+package catalogsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// Store is the local side of a sync. Catalog implements it.
+type Store interface {
+	gutendex.BookStore
+	DeleteBooks(ctx context.Context, ids ...int) error
+	BookIDs(ctx context.Context) ([]int, error)
+}
+
+// ChangeKind describes how a book changed.
+type ChangeKind string
+
+const (
+	// Added marks a book that was not previously in the store.
+	Added ChangeKind = "added"
+	// Updated marks a book whose stored metadata changed.
+	Updated ChangeKind = "updated"
+	// Removed marks a book no longer offered by the remote source.
+	Removed ChangeKind = "removed"
+)
+
+// Change is a single entry of the change log.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	ID   int        `json:"id"`
+	At   time.Time  `json:"at"`
+}
+
+// Watermark records how far a previous sync got.
+type Watermark struct {
+	// MaxID is the highest book ID seen by the sync.
+	MaxID int `json:"max_id"`
+	// SyncedAt is when the sync completed.
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// Result summarizes a sync run.
+type Result struct {
+	Added     []int
+	Updated   []int
+	Removed   []int
+	Watermark Watermark
+}
+
+// Changes returns the result as change log entries stamped with the
+// watermark time.
+func (r *Result) Changes() []Change {
+	var out []Change
+	for _, group := range []struct {
+		kind ChangeKind
+		ids  []int
+	}{{Added, r.Added}, {Updated, r.Updated}, {Removed, r.Removed}} {
+		for _, id := range group.ids {
+			out = append(out, Change{Kind: group.kind, ID: id, At: r.Watermark.SyncedAt})
+		}
+	}
+	return out
+}
+
+// Syncer applies remote changes to a local store.
+type Syncer struct {
+	Remote gutendex.BookSource
+	Store  Store
+
+	// Overlap is the number of already-known books an incremental sync
+	// re-examines past the watermark, to pick up edits to recent entries.
+	Overlap int
+	// TrackDownloads reports books whose only change is their download
+	// count as updated. Such books are written to the store either way.
+	TrackDownloads bool
+	// ChangeLog, when set, receives every change as a JSON line.
+	ChangeLog io.Writer
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// Sync performs an incremental sync from the given watermark. A zero
+// watermark syncs every book, without detecting removals.
+func (s *Syncer) Sync(ctx context.Context, since Watermark) (*Result, error) {
+	res := &Result{Watermark: Watermark{MaxID: since.MaxID}}
+	overlap := s.Overlap
+	it := s.Remote.ListBooks(gutendex.Query{Sort: gutendex.SortDescending}).Context(ctx)
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		b := it.Value()
+		if since.MaxID > 0 && b.ID <= since.MaxID {
+			if overlap <= 0 {
+				break
+			}
+			overlap--
+		}
+		if err := s.apply(ctx, b, res); err != nil {
+			return nil, err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("catalogsync: list remote: %w", err)
+	}
+	return s.finish(res)
+}
+
+// FullSync walks every remote book, upserting changes and deleting stored
+// books the remote no longer offers.
+func (s *Syncer) FullSync(ctx context.Context) (*Result, error) {
+	known, err := s.Store.BookIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("catalogsync: list store: %w", err)
+	}
+	res := &Result{}
+	seen := make(map[int]bool, len(known))
+	it := s.Remote.ListBooks(gutendex.Query{Sort: gutendex.SortDescending}).Context(ctx)
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		b := it.Value()
+		seen[b.ID] = true
+		if err := s.apply(ctx, b, res); err != nil {
+			return nil, err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("catalogsync: list remote: %w", err)
+	}
+	for _, id := range known {
+		if !seen[id] {
+			res.Removed = append(res.Removed, id)
+		}
+	}
+	if len(res.Removed) > 0 {
+		if err := s.Store.DeleteBooks(ctx, res.Removed...); err != nil {
+			return nil, fmt.Errorf("catalogsync: delete: %w", err)
+		}
+	}
+	return s.finish(res)
+}
+
+func (s *Syncer) apply(ctx context.Context, b gutendex.Book, res *Result) error {
+	res.Watermark.MaxID = max(res.Watermark.MaxID, b.ID)
+	old, err := s.Store.GetBook(ctx, b.ID)
+	switch {
+	case gutendex.IsNotFound(err):
+		res.Added = append(res.Added, b.ID)
+	case err != nil:
+		return fmt.Errorf("catalogsync: get %d: %w", b.ID, err)
+	case reflect.DeepEqual(*old, b):
+		return nil
+	default:
+		if s.TrackDownloads || !sameMetadata(*old, b) {
+			res.Updated = append(res.Updated, b.ID)
+		}
+	}
+	if err := s.Store.PutBooks(ctx, b); err != nil {
+		return fmt.Errorf("catalogsync: put %d: %w", b.ID, err)
+	}
+	return nil
+}
+
+func (s *Syncer) finish(res *Result) (*Result, error) {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	res.Watermark.SyncedAt = now()
+	slices.Sort(res.Added)
+	slices.Sort(res.Updated)
+	slices.Sort(res.Removed)
+	if s.ChangeLog != nil {
+		enc := json.NewEncoder(s.ChangeLog)
+		for _, c := range res.Changes() {
+			if err := enc.Encode(c); err != nil {
+				return nil, fmt.Errorf("catalogsync: write change log: %w", err)
+			}
+		}
+	}
+	return res, nil
+}
+
+// sameMetadata reports whether a and b differ at most in download count.
+func sameMetadata(a, b gutendex.Book) bool {
+	a.DownloadCount, b.DownloadCount = 0, 0
+	return reflect.DeepEqual(a, b)
+}
+
+// LoadWatermark reads a watermark saved by SaveWatermark. A missing file
+// yields the zero watermark.
+func LoadWatermark(path string) (Watermark, error) {
+	var w Watermark
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return w, nil
+	}
+	if err != nil {
+		return w, err
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return w, fmt.Errorf("catalogsync: decode watermark: %w", err)
+	}
+	return w, nil
+}
+
+// SaveWatermark writes w to path, replacing the file atomically.
+func SaveWatermark(path string, w Watermark) error {
+	data, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,142 @@
+package catalogsync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+var fixedNow = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func books(ids ...int) []gutendex.Book {
+	var out []gutendex.Book
+	for _, id := range ids {
+		out = append(out, gutendex.Book{ID: id, Title: "t"})
+	}
+	return out
+}
+
+func newSyncer(remote, local *gutendex.Catalog) *Syncer {
+	return &Syncer{Remote: remote, Store: local, Now: func() time.Time { return fixedNow }}
+}
+
+func TestSyncStopsAtWatermark(t *testing.T) {
+	remote := gutendex.NewCatalog(books(1, 2, 3, 4, 5)...)
+	local := gutendex.NewCatalog(books(1, 2, 3)...)
+	// A change below the watermark is invisible to a plain incremental sync.
+	_ = remote.PutBooks(context.Background(), gutendex.Book{ID: 2, Title: "edited"})
+
+	res, err := newSyncer(remote, local).Sync(context.Background(), Watermark{MaxID: 3})
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if !slices.Equal(res.Added, []int{4, 5}) || len(res.Updated) != 0 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if res.Watermark.MaxID != 5 || !res.Watermark.SyncedAt.Equal(fixedNow) {
+		t.Fatalf("unexpected watermark: %+v", res.Watermark)
+	}
+	if local.Len() != 5 {
+		t.Fatalf("store has %d books", local.Len())
+	}
+}
+
+func TestSyncOverlapDetectsUpdates(t *testing.T) {
+	remote := gutendex.NewCatalog(books(1, 2, 3, 4)...)
+	local := gutendex.NewCatalog(books(1, 2, 3)...)
+	_ = remote.PutBooks(context.Background(), gutendex.Book{ID: 3, Title: "edited"})
+	_ = remote.PutBooks(context.Background(), gutendex.Book{ID: 2, Title: "t", DownloadCount: 9})
+
+	s := newSyncer(remote, local)
+	s.Overlap = 2
+	res, err := s.Sync(context.Background(), Watermark{MaxID: 3})
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if !slices.Equal(res.Added, []int{4}) || !slices.Equal(res.Updated, []int{3}) {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if b, _ := local.GetBook(context.Background(), 2); b.DownloadCount != 9 {
+		t.Fatalf("download count change should still be stored")
+	}
+}
+
+func TestFullSyncRemovesAndLogs(t *testing.T) {
+	remote := gutendex.NewCatalog(books(1, 3)...)
+	local := gutendex.NewCatalog(books(1, 2)...)
+	var log bytes.Buffer
+	s := newSyncer(remote, local)
+	s.ChangeLog = &log
+
+	res, err := s.FullSync(context.Background())
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if !slices.Equal(res.Added, []int{3}) || !slices.Equal(res.Removed, []int{2}) {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if _, err := local.GetBook(context.Background(), 2); !gutendex.IsNotFound(err) {
+		t.Fatalf("book 2 should be removed, got %v", err)
+	}
+	want := `{"kind":"added","id":3,"at":"2024-01-02T03:04:05Z"}` + "\n" +
+		`{"kind":"removed","id":2,"at":"2024-01-02T03:04:05Z"}` + "\n"
+	if log.String() != want {
+		t.Fatalf("change log:\n%s", log.String())
+	}
+}
+
+func TestSyncCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := newSyncer(gutendex.NewCatalog(books(1)...), gutendex.NewCatalog()).Sync(ctx, Watermark{})
+	if err == nil || !strings.Contains(err.Error(), "canceled") {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+}
+
+func TestSyncCanceledDuringFetch(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	s := &Syncer{Remote: gutendex.NewClient(gutendex.WithBaseURL(srv.URL)), Store: gutendex.NewCatalog()}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := s.Sync(ctx, Watermark{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Fatalf("Sync returned %v after its context ended", d)
+	}
+}
+
+func TestWatermarkRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	w, err := LoadWatermark(path)
+	if err != nil || w.MaxID != 0 {
+		t.Fatalf("missing file should give zero watermark, got %+v, %v", w, err)
+	}
+	if err := SaveWatermark(path, Watermark{MaxID: 42, SyncedAt: fixedNow}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	w, err = LoadWatermark(path)
+	if err != nil || w.MaxID != 42 || !w.SyncedAt.Equal(fixedNow) {
+		t.Fatalf("load = %+v, %v", w, err)
+	}
+}
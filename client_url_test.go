@@ -1,6 +1,7 @@
 package gutendex
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -40,3 +41,45 @@ func TestSearchDelegatesToListBooks(t *testing.T) {
 		t.Fatalf("expected request to %q, got %q", want, got)
 	}
 }
+
+func TestGetBooksRequestsIDsAndPreservesOrder(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query().Get("ids")
+		_, _ = fmt.Fprint(w, `{"count":2,"next":null,"previous":null,"results":[{"id":2},{"id":5}]}`)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	books, err := c.GetBooks(context.Background(), []int{5, 2, 9})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "5,2,9" {
+		t.Fatalf("ids = %q", got)
+	}
+	if len(books) != 2 || books[0].ID != 5 || books[1].ID != 2 {
+		t.Fatalf("unexpected books: %+v", books)
+	}
+}
+
+func TestGetPageRequestsPage(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.String()
+		_, _ = fmt.Fprint(w, `{"count":40,"next":null,"previous":"p1","results":[{"id":9}]}`)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	p, err := c.GetPage(context.Background(), Query{Topic: "poetry"}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "/books?page=2&topic=poetry"; got != want {
+		t.Fatalf("expected request to %q, got %q", want, got)
+	}
+	if p.Count != 40 || len(p.Results) != 1 || p.Previous == nil {
+		t.Fatalf("unexpected page: %+v", p)
+	}
+}
@@ -0,0 +1,191 @@
+// Command gutendex-popularity records book download counts into a local
+// snapshot file and reports how books moved between snapshots.
+//
+// Usage:
+//
+//	gutendex-popularity record [-store file] [-top n] [-watch ids] [-every interval]
+//	gutendex-popularity diff [-store file]
+//	gutendex-popularity trending [-store file] [-n count] [-min downloads]
+//
+// diff and trending compare the last two snapshots in the store.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+	"github.com/alex-rs/go-gutendex/popularity"
+)
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "record":
+		err = record(ctx, args)
+	case "diff":
+		err = diff(args, os.Stdout)
+	case "trending":
+		err = trending(args, os.Stdout)
+	default:
+		usage()
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	log.Fatal("usage: gutendex-popularity record|diff|trending [flags]")
+}
+
+func record(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("record", flag.ExitOnError)
+	path := fs.String("store", "popularity.jsonl", "snapshot file")
+	base := fs.String("base-url", "", "API base URL")
+	top := fs.Int("top", 100, "number of most popular books to record")
+	watch := fs.String("watch", "", "comma-separated book IDs to record as well")
+	every := fs.Duration("every", 0, "record repeatedly at this interval")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := parseIDs(*watch)
+	if err != nil {
+		return err
+	}
+	var opts []gutendex.Option
+	if *base != "" {
+		opts = append(opts, gutendex.WithBaseURL(*base))
+	}
+	r := &popularity.Recorder{
+		Source:    gutendex.NewClient(opts...),
+		Store:     popularity.Open(*path),
+		Top:       *top,
+		Watchlist: ids,
+	}
+	if *every > 0 {
+		return r.Run(ctx, *every)
+	}
+	snap, err := r.Record(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("recorded %d books to %s", len(snap.Entries), *path)
+	return nil
+}
+
+func parseIDs(s string) ([]int, error) {
+	var ids []int
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		id, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -watch ID %q", f)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// lastTwo returns the last two snapshots of the store at path.
+func lastTwo(path string) (prev, cur popularity.Snapshot, err error) {
+	snaps, err := popularity.Open(path).Latest(2)
+	if err != nil {
+		return prev, cur, err
+	}
+	if len(snaps) < 2 {
+		return prev, cur, fmt.Errorf("%s: need two snapshots, have %d", path, len(snaps))
+	}
+	return snaps[0], snaps[1], nil
+}
+
+func diff(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	path := fs.String("store", "popularity.jsonl", "snapshot file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	prev, cur, err := lastTwo(*path)
+	if err != nil {
+		return err
+	}
+	return printChanges(w, prev, cur, popularity.Compare(prev, cur))
+}
+
+func trending(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("trending", flag.ExitOnError)
+	path := fs.String("store", "popularity.jsonl", "snapshot file")
+	n := fs.Int("n", 20, "number of books to list")
+	floor := fs.Int("min", 100, "minimum downloads in the earlier snapshot")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	prev, cur, err := lastTwo(*path)
+	if err != nil {
+		return err
+	}
+	return printChanges(w, prev, cur, popularity.Trending(prev, cur, *n, *floor))
+}
+
+func printChanges(w io.Writer, prev, cur popularity.Snapshot, changes []popularity.Change) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintf(tw, "# %s -> %s\n", prev.Taken.Format(time.RFC3339), cur.Taken.Format(time.RFC3339)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(tw, "RANK\tMOVE\tDOWNLOADS\tGROWTH\tID\tTITLE"); err != nil {
+		return err
+	}
+	for _, c := range changes {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%d\t%s\n", rank(c.Rank), move(c), c.Downloads, growth(c), c.ID, c.Title); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func rank(r int) string {
+	if r == 0 {
+		return "-"
+	}
+	return strconv.Itoa(r)
+}
+
+func move(c popularity.Change) string {
+	switch {
+	case c.New:
+		return "new"
+	case c.Dropped:
+		return "dropped"
+	case c.RankDelta > 0:
+		return fmt.Sprintf("+%d", c.RankDelta)
+	case c.RankDelta < 0:
+		return strconv.Itoa(c.RankDelta)
+	}
+	return "="
+}
+
+func growth(c popularity.Change) string {
+	if c.New || c.Dropped || c.PrevDownloads == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", c.Growth*100)
+}
@@ -0,0 +1,89 @@
+// Command gutendex-server serves a Gutendex-compatible API from a catalog
+// snapshot file of JSON-encoded books, one per line.
+//
+// Sending SIGHUP reloads the snapshot without dropping connections; SIGINT
+// and SIGTERM shut the server down gracefully.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+	"github.com/alex-rs/go-gutendex/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8000", "listen address")
+	path := flag.String("catalog", "catalog.jsonl", "catalog snapshot file")
+	base := flag.String("base-url", "", "public base URL for pagination links")
+	pageSize := flag.Int("page-size", server.DefaultPageSize, "results per page")
+	grace := flag.Duration("shutdown-timeout", 10*time.Second, "graceful shutdown timeout")
+	flag.Parse()
+
+	catalog, err := load(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := []server.Option{server.WithPageSize(*pageSize)}
+	if *base != "" {
+		u, err := url.Parse(*base)
+		if err != nil {
+			log.Fatalf("invalid -base-url: %v", err)
+		}
+		opts = append(opts, server.WithBaseURL(u))
+	}
+	srv := server.New(catalog, opts...)
+	httpSrv := &http.Server{Addr: *addr, Handler: srv, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		for range hup {
+			c, err := load(*path)
+			if err != nil {
+				log.Printf("reload failed, keeping current catalog: %v", err)
+				continue
+			}
+			srv.Reload(c)
+			log.Printf("reloaded %d books from %s", c.Len(), *path)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	shutdown := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *grace)
+		defer cancel()
+		shutdown <- httpSrv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("serving %d books on %s", catalog.Len(), *addr)
+	if err := httpSrv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	// ListenAndServe returns as soon as Shutdown is called; wait for
+	// in-flight requests to finish before exiting.
+	if err := <-shutdown; err != nil {
+		log.Fatalf("shutdown: %v", err)
+	}
+}
+
+func load(path string) (*gutendex.Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return gutendex.DecodeCatalog(f)
+}
@@ -17,6 +17,9 @@ const (
 	ErrRateLimited
 	// ErrServer indicates a server side error (5xx).
 	ErrServer
+	// ErrInvalidQuery indicates a query rejected before any request was
+	// made, such as one with an unknown language code.
+	ErrInvalidQuery
 )
 
 // Error provides structured error information for operations.
@@ -58,3 +61,10 @@ var errNotFound = &Error{Kind: ErrNotFound}
 func IsNotFound(err error) bool {
 	return errors.Is(err, errNotFound)
 }
+
+var errInvalidQuery = &Error{Kind: ErrInvalidQuery}
+
+// IsInvalidQuery reports whether err represents a query validation error.
+func IsInvalidQuery(err error) bool {
+	return errors.Is(err, errInvalidQuery)
+}
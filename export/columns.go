@@ -0,0 +1,130 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// Column describes one exported field of a book.
+type Column struct {
+	// Name is the header in CSV/TSV output and the key in JSON Lines.
+	Name string
+	// Values extracts the field. Single-valued columns return one element.
+	Values func(gutendex.Book) []string
+	// Multi marks multi-valued columns, which are joined with the
+	// configured separator in CSV/TSV and written as arrays in JSON Lines.
+	Multi bool
+	// Numeric writes the value unquoted in JSON Lines.
+	Numeric bool
+}
+
+// Predefined columns.
+var (
+	ID = Column{Name: "id", Numeric: true, Values: func(b gutendex.Book) []string {
+		return []string{strconv.Itoa(b.ID)}
+	}}
+	Title = Column{Name: "title", Values: func(b gutendex.Book) []string {
+		return []string{b.Title}
+	}}
+	Authors = Column{Name: "authors", Multi: true, Values: func(b gutendex.Book) []string {
+		return names(b.Authors)
+	}}
+	AuthorYears = Column{Name: "author_years", Multi: true, Values: func(b gutendex.Book) []string {
+		out := make([]string, len(b.Authors))
+		for i, p := range b.Authors {
+			out[i] = lifeSpan(p)
+		}
+		return out
+	}}
+	Translators = Column{Name: "translators", Multi: true, Values: func(b gutendex.Book) []string {
+		return names(b.Translators)
+	}}
+	Languages = Column{Name: "languages", Multi: true, Values: func(b gutendex.Book) []string {
+		return b.Languages
+	}}
+	Subjects = Column{Name: "subjects", Multi: true, Values: func(b gutendex.Book) []string {
+		return b.Subjects
+	}}
+	Bookshelves = Column{Name: "bookshelves", Multi: true, Values: func(b gutendex.Book) []string {
+		return b.Bookshelves
+	}}
+	MediaType = Column{Name: "media_type", Values: func(b gutendex.Book) []string {
+		return []string{b.MediaType}
+	}}
+	DownloadCount = Column{Name: "download_count", Numeric: true, Values: func(b gutendex.Book) []string {
+		return []string{strconv.Itoa(b.DownloadCount)}
+	}}
+)
+
+// DefaultColumns are used when Options.Columns is empty.
+var DefaultColumns = []Column{ID, Title, Authors, AuthorYears, Languages, Subjects, Bookshelves, DownloadCount}
+
+// Format returns a column holding the URL of the given MIME type, such as
+// "application/epub+zip". A bare type like "text/plain" also matches
+// variants with parameters, e.g. "text/plain; charset=utf-8". Among
+// several variants the UTF-8 one is preferred, then the first in
+// lexical order, so that repeated exports agree.
+func Format(mime string) Column {
+	return Column{Name: mime, Values: func(b gutendex.Book) []string {
+		if u, ok := b.Formats[mime]; ok {
+			return []string{u}
+		}
+		best := ""
+		for k := range b.Formats {
+			if !strings.HasPrefix(k, mime+";") {
+				continue
+			}
+			if best == "" || isUTF8(k) && !isUTF8(best) || isUTF8(k) == isUTF8(best) && k < best {
+				best = k
+			}
+		}
+		return []string{b.Formats[best]}
+	}}
+}
+
+func isUTF8(mime string) bool {
+	return strings.HasSuffix(strings.ToLower(mime), "charset=utf-8")
+}
+
+// ParseColumns resolves a comma-separated list of column names, as accepted
+// on command lines. Format columns are written "format:<mime type>".
+func ParseColumns(spec string) ([]Column, error) {
+	byName := make(map[string]Column)
+	for _, c := range []Column{ID, Title, Authors, AuthorYears, Translators, Languages, Subjects, Bookshelves, MediaType, DownloadCount} {
+		byName[c.Name] = c
+	}
+	var cols []Column
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if mime, ok := strings.CutPrefix(name, "format:"); ok && mime != "" {
+			cols = append(cols, Format(mime))
+			continue
+		}
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("export: unknown column %q", name)
+		}
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
+
+func names(people []gutendex.Person) []string {
+	out := make([]string, len(people))
+	for i, p := range people {
+		out[i] = p.Name
+	}
+	return out
+}
+
+// lifeSpan is Person.LifeSpan with "?-?" for people without known years,
+// so that values line up with the authors column.
+func lifeSpan(p gutendex.Person) string {
+	if s := p.LifeSpan(); s != "" {
+		return s
+	}
+	return "?-?"
+}
@@ -0,0 +1,192 @@
+// Package export streams books to CSV, TSV and JSON Lines with configurable
+// columns.
+//
+//	w := export.NewWriter(os.Stdout, export.CSV, export.Options{
+//		Columns: []export.Column{export.ID, export.Title, export.Authors, export.Format("application/epub+zip")},
+//	})
+//	if err := w.WriteIter(client.ListBooks(q)); err != nil { ... }
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"iter"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// FileFormat selects the output encoding.
+type FileFormat int
+
+const (
+	// CSV writes RFC 4180 comma-separated values with quoting as needed.
+	CSV FileFormat = iota
+	// TSV writes tab-separated values. Tabs and line breaks inside values
+	// are replaced by spaces, since TSV has no quoting.
+	TSV
+	// JSONL writes one JSON object per book.
+	JSONL
+)
+
+// Options configures a Writer.
+type Options struct {
+	// Columns lists the exported fields. Defaults to DefaultColumns.
+	Columns []Column
+	// Separator joins multi-valued fields in CSV and TSV. Defaults to "; ".
+	Separator string
+	// NoHeader omits the header row in CSV and TSV.
+	NoHeader bool
+}
+
+// Writer encodes books one at a time, so arbitrarily large result sets can
+// be exported without holding them in memory.
+type Writer struct {
+	format FileFormat
+	opts   Options
+	bw     *bufio.Writer
+	csv    *csv.Writer
+	header bool
+	row    []string
+}
+
+// NewWriter returns a Writer encoding to w in the given format.
+func NewWriter(w io.Writer, format FileFormat, opts Options) *Writer {
+	if len(opts.Columns) == 0 {
+		opts.Columns = DefaultColumns
+	}
+	if opts.Separator == "" {
+		opts.Separator = "; "
+	}
+	ew := &Writer{format: format, opts: opts, bw: bufio.NewWriter(w), header: !opts.NoHeader}
+	if format == CSV {
+		ew.csv = csv.NewWriter(ew.bw)
+	}
+	return ew
+}
+
+// Write encodes a single book.
+func (w *Writer) Write(b gutendex.Book) error {
+	if w.format == JSONL {
+		return w.writeJSON(b)
+	}
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.row = w.row[:0]
+	for _, c := range w.opts.Columns {
+		w.row = append(w.row, strings.Join(c.Values(b), w.opts.Separator))
+	}
+	return w.writeRow()
+}
+
+// WriteSeq encodes every book of seq and flushes the output.
+func (w *Writer) WriteSeq(seq iter.Seq[gutendex.Book]) error {
+	for b := range seq {
+		if err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// WriteIter encodes every book produced by it, following pagination, and
+// flushes the output. It returns the iterator's error, if any.
+func (w *Writer) WriteIter(it *gutendex.Iter[gutendex.Book]) error {
+	for it.Next() {
+		if err := w.Write(it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// Flush writes any buffered data to the underlying writer. The CSV and
+// TSV header is written even if no book was, so that an empty result is
+// still a valid file.
+func (w *Writer) Flush() error {
+	if w.format != JSONL {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return w.bw.Flush()
+}
+
+// writeHeader writes the header row unless it was written or disabled.
+func (w *Writer) writeHeader() error {
+	if !w.header {
+		return nil
+	}
+	w.header = false
+	w.row = w.row[:0]
+	for _, c := range w.opts.Columns {
+		w.row = append(w.row, c.Name)
+	}
+	return w.writeRow()
+}
+
+func (w *Writer) writeRow() error {
+	if w.csv != nil {
+		return w.csv.Write(w.row)
+	}
+	for i, v := range w.row {
+		if i > 0 {
+			if err := w.bw.WriteByte('\t'); err != nil {
+				return err
+			}
+		}
+		if _, err := w.bw.WriteString(tsvEscaper.Replace(v)); err != nil {
+			return err
+		}
+	}
+	return w.bw.WriteByte('\n')
+}
+
+var tsvEscaper = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+func (w *Writer) writeJSON(b gutendex.Book) error {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, c := range w.opts.Columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(c.Name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		vals := c.Values(b)
+		var v any
+		switch {
+		case c.Multi:
+			if vals == nil {
+				vals = []string{}
+			}
+			v = vals
+		case c.Numeric && len(vals) == 1:
+			v = json.RawMessage(vals[0])
+		case len(vals) > 0:
+			v = vals[0]
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	buf.WriteString("}\n")
+	_, err := w.bw.Write(buf.Bytes())
+	return err
+}
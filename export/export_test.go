@@ -0,0 +1,143 @@
+package export
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+func intPtr(n int) *int { return &n }
+
+var sample = []gutendex.Book{
+	{
+		ID:    1342,
+		Title: "Pride and Prejudice",
+		Authors: []gutendex.Person{
+			{Name: "Austen, Jane", BirthYear: intPtr(1775), DeathYear: intPtr(1817)},
+		},
+		Languages:     []string{"en"},
+		Subjects:      []string{"Courtship -- Fiction", "England -- Fiction"},
+		Formats:       map[string]string{"text/html; charset=utf-8": "https://example/1342.html"},
+		DownloadCount: 100,
+	},
+	{
+		ID:      2,
+		Title:   "Odd \"title\",\twith\nbreaks",
+		Authors: []gutendex.Person{{Name: "Homer", BirthYear: intPtr(-750)}, {Name: "Anon"}},
+	},
+}
+
+func TestCSV(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, CSV, Options{Columns: []Column{ID, Title, Authors, AuthorYears, Subjects, Format("text/html")}})
+	if err := w.WriteSeq(slices.Values(sample)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := "id,title,authors,author_years,subjects,text/html\n" +
+		"1342,Pride and Prejudice,\"Austen, Jane\",1775-1817,Courtship -- Fiction; England -- Fiction,https://example/1342.html\n" +
+		"2,\"Odd \"\"title\"\",\twith\nbreaks\",Homer; Anon,750 BCE-?; ?-?,,\n"
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestTSV(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, TSV, Options{Columns: []Column{ID, Title, Authors}, Separator: "|", NoHeader: true})
+	if err := w.WriteSeq(slices.Values(sample)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := "1342\tPride and Prejudice\tAusten, Jane\n" +
+		"2\tOdd \"title\", with breaks\tHomer|Anon\n"
+	if buf.String() != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, JSONL, Options{Columns: []Column{ID, Title, Languages, DownloadCount}})
+	if err := w.WriteSeq(slices.Values(sample)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if lines[0] != `{"id":1342,"title":"Pride and Prejudice","languages":["en"],"download_count":100}` {
+		t.Fatalf("line 0: %s", lines[0])
+	}
+	if lines[1] != `{"id":2,"title":"Odd \"title\",\twith\nbreaks","languages":[],"download_count":0}` {
+		t.Fatalf("line 1: %s", lines[1])
+	}
+}
+
+func TestWriteIter(t *testing.T) {
+	var buf bytes.Buffer
+	c := gutendex.NewCatalog(sample...)
+	if err := NewWriter(&buf, TSV, Options{Columns: []Column{ID}}).WriteIter(c.ListBooks(gutendex.Query{})); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if buf.String() != "id\n1342\n2\n" {
+		t.Fatalf("got %q", buf.String())
+	}
+}
+
+func TestFormatVariants(t *testing.T) {
+	b := gutendex.Book{Formats: map[string]string{
+		"text/plain; charset=us-ascii":   "ascii",
+		"text/plain; charset=iso-8859-1": "latin1",
+		"text/plain; charset=utf-8":      "utf8",
+		"text/html; charset=iso-8859-1":  "html-latin1",
+		"text/html; charset=us-ascii":    "html-ascii",
+	}}
+	for range 20 {
+		if got := Format("text/plain").Values(b); got[0] != "utf8" {
+			t.Fatalf("text/plain = %v, want utf8", got)
+		}
+		if got := Format("text/html").Values(b); got[0] != "html-latin1" {
+			t.Fatalf("text/html = %v, want html-latin1", got)
+		}
+	}
+	if got := Format("application/epub+zip").Values(b); got[0] != "" {
+		t.Errorf("missing format = %v", got)
+	}
+}
+
+func TestEmptyOutput(t *testing.T) {
+	for _, tt := range []struct {
+		format FileFormat
+		opts   Options
+		want   string
+	}{
+		{CSV, Options{Columns: []Column{ID, Title}}, "id,title\n"},
+		{TSV, Options{Columns: []Column{ID, Title}}, "id\ttitle\n"},
+		{TSV, Options{Columns: []Column{ID}, NoHeader: true}, ""},
+		{JSONL, Options{}, ""},
+	} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, tt.format, tt.opts)
+		if err := w.WriteSeq(slices.Values([]gutendex.Book(nil))); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("format %d: got %q, want %q", tt.format, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	cols, err := ParseColumns("id, title,format:application/epub+zip")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(cols) != 3 || cols[2].Name != "application/epub+zip" {
+		t.Fatalf("unexpected columns: %+v", cols)
+	}
+	if _, err := ParseColumns("id,nope"); err == nil {
+		t.Fatalf("expected unknown column error")
+	}
+}
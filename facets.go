@@ -0,0 +1,219 @@
+package gutendex
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultFacetSample is the number of books examined by Facets when
+// FacetOptions.Sample is not set.
+const DefaultFacetSample = 1000
+
+// FacetOptions configures Facets.
+type FacetOptions struct {
+	// Sample bounds the number of books examined. Counts over a sample
+	// approximate the proportions of the full result.
+	Sample int
+	// Top, if positive, keeps only the most frequent values of each facet.
+	Top int
+}
+
+// FacetCount is a facet value and the number of books that have it.
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// Facets holds per-value book counts over the results of a query, for
+// building filter sidebars. Values are ordered by count, then by value.
+type Facets struct {
+	// Examined is the number of books counted.
+	Examined int
+	// Total is the number of books matching the query, or -1 if the source
+	// does not report it.
+	Total int
+	// Sampled reports whether counting stopped at the sample size before
+	// the last result.
+	Sampled bool
+
+	// Bookshelves counts bookshelves without their "Browsing: " or
+	// "Category: " prefix.
+	Bookshelves []FacetCount
+	Languages   []FacetCount
+	// Subjects counts main headings, the part of a subject before any
+	// " -- " subdivision.
+	Subjects []FacetCount
+	// Copyright counts "public domain", "copyrighted" and "unknown".
+	Copyright []FacetCount
+	// AuthorCenturies counts the centuries authors were born in, e.g.
+	// "19th century" or "5th century BCE", using the death year when the
+	// birth year is unknown.
+	AuthorCenturies []FacetCount
+	MediaTypes      []FacetCount
+}
+
+// Facets counts bookshelves, languages, subjects, copyright status, author
+// centuries and media types over the books matching q.
+func (c *Client) Facets(ctx context.Context, q Query, opts FacetOptions) (*Facets, error) {
+	return countFacets(ctx, c, q, opts)
+}
+
+// Facets counts facet values over the catalog books matching q, as for
+// Client.Facets.
+func (c *Catalog) Facets(ctx context.Context, q Query, opts FacetOptions) (*Facets, error) {
+	return countFacets(ctx, c, q, opts)
+}
+
+func countFacets(ctx context.Context, src BookSource, q Query, opts FacetOptions) (*Facets, error) {
+	sample := opts.Sample
+	if sample <= 0 {
+		sample = DefaultFacetSample
+	}
+	var shelves, langs, subjects, copyright, centuries, media facetCounter
+	f := &Facets{}
+	it := src.ListBooks(q)
+	it.ctx = ctx
+	for f.Examined < sample && it.Next() {
+		b := it.Value()
+		f.Examined++
+		for _, s := range b.Bookshelves {
+			shelves.add(ShelfLabel(s))
+		}
+		for _, l := range b.Languages {
+			langs.add(l)
+		}
+		for _, s := range b.Subjects {
+			main, _, _ := strings.Cut(s, " -- ")
+			subjects.add(strings.TrimSpace(main))
+		}
+		copyright.add(copyrightLabel(b.Copyright))
+		for _, p := range b.Authors {
+			if y := p.BirthYear; y != nil {
+				centuries.add(century(*y))
+			} else if y := p.DeathYear; y != nil {
+				centuries.add(century(*y))
+			}
+		}
+		if b.MediaType != "" {
+			media.add(b.MediaType)
+		}
+		// A value seen twice in one book, such as two authors of the same
+		// century, counts once.
+		for _, c := range []*facetCounter{&shelves, &langs, &subjects, &copyright, &centuries, &media} {
+			c.endBook()
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	f.Total = it.count
+	// Stop without fetching another page; with an unknown total, assume
+	// there was more.
+	f.Sampled = f.Examined == sample && (f.Total < 0 || f.Total > sample)
+	f.Bookshelves = shelves.result(opts.Top)
+	f.Languages = langs.result(opts.Top)
+	f.Subjects = subjects.result(opts.Top)
+	f.Copyright = copyright.result(opts.Top)
+	f.AuthorCenturies = centuries.result(opts.Top)
+	f.MediaTypes = media.result(opts.Top)
+	return f, nil
+}
+
+// facetCounter counts each value at most once per book.
+type facetCounter struct {
+	counts map[string]int
+	book   map[string]bool
+}
+
+func (c *facetCounter) add(v string) {
+	if c.book == nil {
+		c.book = make(map[string]bool)
+	}
+	c.book[v] = true
+}
+
+func (c *facetCounter) endBook() {
+	if len(c.book) == 0 {
+		return
+	}
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	for v := range c.book {
+		c.counts[v]++
+	}
+	clear(c.book)
+}
+
+func (c *facetCounter) result(top int) []FacetCount {
+	out := make([]FacetCount, 0, len(c.counts))
+	for v, n := range c.counts {
+		out = append(out, FacetCount{Value: v, Count: n})
+	}
+	slices.SortFunc(out, func(a, b FacetCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+	if top > 0 && len(out) > top {
+		out = out[:top]
+	}
+	return out
+}
+
+// ShelfLabel returns the name of a bookshelf without the "Browsing: " or
+// "Category: " prefix Project Gutenberg gives some shelves, e.g. "Science
+// Fiction" for "Browsing: Science Fiction".
+func ShelfLabel(s string) string {
+	for _, prefix := range []string{"Browsing: ", "Category: "} {
+		if rest, ok := strings.CutPrefix(s, prefix); ok {
+			return rest
+		}
+	}
+	return s
+}
+
+func copyrightLabel(c *bool) string {
+	switch {
+	case c == nil:
+		return "unknown"
+	case *c:
+		return "copyrighted"
+	default:
+		return "public domain"
+	}
+}
+
+// century names the century of year y, e.g. "19th century" for 1817 and
+// "8th century BCE" for -750.
+func century(y int) string {
+	suffix := ""
+	if y < 0 {
+		y, suffix = -y, " BCE"
+	}
+	n := (y + 99) / 100
+	if n == 0 {
+		n = 1
+	}
+	return ordinal(n) + " century" + suffix
+}
+
+func ordinal(n int) string {
+	s := strconv.Itoa(n)
+	if n%100 >= 11 && n%100 <= 13 {
+		return s + "th"
+	}
+	switch n % 10 {
+	case 1:
+		return s + "st"
+	case 2:
+		return s + "nd"
+	case 3:
+		return s + "rd"
+	}
+	return s + "th"
+}
@@ -0,0 +1,91 @@
+package gutendex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCatalogFacets(t *testing.T) {
+	pd := false
+	books := []Book{
+		{ID: 1, Bookshelves: []string{"Browsing: Fiction", "Best Books Ever Listings"}, Languages: []string{"en"},
+			Subjects: []string{"England -- Fiction", "England -- Social life and customs"}, Copyright: &pd, MediaType: "Text",
+			Authors: []Person{{Name: "A", BirthYear: year(1775)}, {Name: "B", BirthYear: year(1790)}}},
+		{ID: 2, Bookshelves: []string{"Category: Fiction"}, Languages: []string{"fr", "en"}, Subjects: []string{"Paris (France) -- Fiction"},
+			MediaType: "Text", Authors: []Person{{Name: "C", DeathYear: year(1885)}}},
+		{ID: 3, Languages: []string{"grc"}, Copyright: &pd, MediaType: "Sound", Authors: []Person{{Name: "Homer", BirthYear: year(-750)}}},
+	}
+	f, err := NewCatalog(books...).Facets(context.Background(), Query{}, FacetOptions{})
+	if err != nil {
+		t.Fatalf("Facets: %v", err)
+	}
+	if f.Examined != 3 || f.Total != 3 || f.Sampled {
+		t.Fatalf("examined %d of %d, sampled %v", f.Examined, f.Total, f.Sampled)
+	}
+	checks := []struct {
+		name      string
+		got, want []FacetCount
+	}{
+		{"bookshelves", f.Bookshelves, []FacetCount{{"Fiction", 2}, {"Best Books Ever Listings", 1}}},
+		{"languages", f.Languages, []FacetCount{{"en", 2}, {"fr", 1}, {"grc", 1}}},
+		{"subjects", f.Subjects, []FacetCount{{"England", 1}, {"Paris (France)", 1}}},
+		{"copyright", f.Copyright, []FacetCount{{"public domain", 2}, {"unknown", 1}}},
+		{"centuries", f.AuthorCenturies, []FacetCount{{"18th century", 1}, {"19th century", 1}, {"8th century BCE", 1}}},
+		{"media", f.MediaTypes, []FacetCount{{"Text", 2}, {"Sound", 1}}},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestClientFacetsSample(t *testing.T) {
+	requests := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		next := srv.URL + "/books?page=2"
+		_ = json.NewEncoder(w).Encode(Page[Book]{
+			Count:   500,
+			Next:    &next,
+			Results: []Book{{ID: requests*2 - 1, Languages: []string{"en"}}, {ID: requests * 2, Languages: []string{"de"}}},
+		})
+	}))
+	defer srv.Close()
+
+	f, err := newTestClient(srv.URL).Facets(context.Background(), Query{}, FacetOptions{Sample: 4, Top: 1})
+	if err != nil {
+		t.Fatalf("Facets: %v", err)
+	}
+	if f.Examined != 4 || f.Total != 500 || !f.Sampled || requests != 2 {
+		t.Fatalf("examined %d of %d, sampled %v, %d requests", f.Examined, f.Total, f.Sampled, requests)
+	}
+	if want := []FacetCount{{"de", 2}}; !reflect.DeepEqual(f.Languages, want) {
+		t.Fatalf("languages = %v, want %v", f.Languages, want)
+	}
+}
+
+func TestCentury(t *testing.T) {
+	for y, want := range map[int]string{1817: "19th century", 1900: "19th century", 1901: "20th century", 1111: "12th century", -750: "8th century BCE", 0: "1st century"} {
+		if got := century(y); got != want {
+			t.Errorf("century(%d) = %q, want %q", y, got, want)
+		}
+	}
+}
+
+func TestShelfLabel(t *testing.T) {
+	for in, want := range map[string]string{
+		"Browsing: Science Fiction": "Science Fiction",
+		"Category: Novels":          "Novels",
+		"Gothic Fiction":            "Gothic Fiction",
+	} {
+		if got := ShelfLabel(in); got != want {
+			t.Errorf("ShelfLabel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package gutendex
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultFetchConcurrency is the number of FetchMany workers used when
+// FetchOptions.Concurrency is not set.
+const DefaultFetchConcurrency = 4
+
+// FetchOptions configures FetchMany.
+type FetchOptions struct {
+	// Concurrency is the maximum number of requests in flight.
+	Concurrency int
+	// Adaptive halves the number of requests in flight whenever one ends
+	// rate limited and raises it by one after a run of successes, up to
+	// Concurrency.
+	Adaptive bool
+}
+
+// FetchResult is the outcome of fetching one book.
+type FetchResult struct {
+	ID   int
+	Book *Book
+	Err  error
+}
+
+// FetchSummary counts the outcomes delivered on Results by a FetchMany run.
+// IDs skipped or results dropped because the context ended are not
+// counted.
+type FetchSummary struct {
+	Succeeded int
+	NotFound  int
+	Failed    int
+}
+
+// BulkFetch is a running FetchMany.
+type BulkFetch struct {
+	results chan FetchResult
+	done    chan struct{}
+	gate    *gate
+
+	mu      sync.Mutex
+	summary FetchSummary
+}
+
+// FetchMany fetches the books with the given IDs using a bounded pool of
+// workers. All workers share the client's rate limiter. Results are
+// delivered in completion order on Results, which must be drained unless
+// ctx is canceled.
+func (c *Client) FetchMany(ctx context.Context, ids []int, opts FetchOptions) *BulkFetch {
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = DefaultFetchConcurrency
+	}
+	f := &BulkFetch{
+		results: make(chan FetchResult),
+		done:    make(chan struct{}),
+		gate:    newGate(workers, opts.Adaptive),
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for _, id := range ids {
+			select {
+			case jobs <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				if err := f.gate.acquire(ctx); err != nil {
+					return
+				}
+				b, err := c.GetBook(ctx, id)
+				f.gate.release(errors.Is(err, &Error{Kind: ErrRateLimited}))
+				select {
+				case f.results <- FetchResult{ID: id, Book: b, Err: err}:
+					f.record(err)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(f.results)
+		close(f.done)
+	}()
+	return f
+}
+
+// Results returns the channel of per-ID results. It is closed once every
+// ID has been processed or the context has ended.
+func (f *BulkFetch) Results() <-chan FetchResult { return f.results }
+
+// Summary waits for the fetch to finish and returns its outcome counts.
+func (f *BulkFetch) Summary() FetchSummary {
+	<-f.done
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.summary
+}
+
+// Concurrency reports the current number of requests allowed in flight.
+func (f *BulkFetch) Concurrency() int { return f.gate.current() }
+
+func (f *BulkFetch) record(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case err == nil:
+		f.summary.Succeeded++
+	case IsNotFound(err):
+		f.summary.NotFound++
+	default:
+		f.summary.Failed++
+	}
+}
+
+// gate limits concurrent work to a bound that, when adaptive, shrinks
+// multiplicatively on throttling and grows additively on success.
+type gate struct {
+	mu       sync.Mutex
+	max      int
+	limit    int
+	active   int
+	streak   int
+	adaptive bool
+	wake     chan struct{}
+}
+
+func newGate(n int, adaptive bool) *gate {
+	return &gate{max: n, limit: n, adaptive: adaptive, wake: make(chan struct{})}
+}
+
+func (g *gate) acquire(ctx context.Context) error {
+	for {
+		g.mu.Lock()
+		if g.active < g.limit {
+			g.active++
+			g.mu.Unlock()
+			return nil
+		}
+		wake := g.wake
+		g.mu.Unlock()
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (g *gate) release(throttled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.active--
+	if g.adaptive {
+		if throttled {
+			g.limit = max(1, g.limit/2)
+			g.streak = 0
+		} else if g.streak++; g.streak >= g.limit && g.limit < g.max {
+			g.limit++
+			g.streak = 0
+		}
+	}
+	close(g.wake)
+	g.wake = make(chan struct{})
+}
+
+func (g *gate) current() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.limit
+}
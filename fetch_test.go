@@ -0,0 +1,132 @@
+package gutendex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchMany(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/books/")
+		switch id {
+		case "404":
+			w.WriteHeader(http.StatusNotFound)
+		case "400":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			_, _ = fmt.Fprintf(w, `{"id":%s}`, id)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	ids := []int{1, 2, 3, 404, 5, 6, 400, 8}
+	f := c.FetchMany(context.Background(), ids, FetchOptions{Concurrency: 3})
+	seen := map[int]bool{}
+	for res := range f.Results() {
+		seen[res.ID] = true
+		if res.Err == nil && res.Book.ID != res.ID {
+			t.Fatalf("result %d carries book %d", res.ID, res.Book.ID)
+		}
+	}
+	if len(seen) != len(ids) {
+		t.Fatalf("got results for %d ids, want %d", len(seen), len(ids))
+	}
+	if got, want := f.Summary(), (FetchSummary{Succeeded: 6, NotFound: 1, Failed: 1}); got != want {
+		t.Fatalf("summary = %+v, want %+v", got, want)
+	}
+	if peak.Load() > 3 {
+		t.Fatalf("peak concurrency %d exceeds 3", peak.Load())
+	}
+}
+
+func TestFetchManyCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":1}`)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := newTestClient(srv.URL)
+	f := c.FetchMany(ctx, []int{1, 2, 3, 4, 5, 6}, FetchOptions{Concurrency: 1})
+	<-f.Results()
+	received := 1
+	cancel()
+	// Let the worker give up sending its next result before draining.
+	time.Sleep(50 * time.Millisecond)
+	for range f.Results() {
+		received++
+	}
+	s := f.Summary()
+	if s.Succeeded+s.Failed+s.NotFound >= 6 {
+		t.Fatalf("expected cancellation to skip ids, got %+v", s)
+	}
+	if total := s.Succeeded + s.Failed + s.NotFound; total != received {
+		t.Fatalf("summary counts %d results, caller received %d", total, received)
+	}
+}
+
+func TestGateAdaptive(t *testing.T) {
+	g := newGate(8, true)
+	ctx := context.Background()
+	_ = g.acquire(ctx)
+	g.release(true)
+	_ = g.acquire(ctx)
+	g.release(true)
+	if g.current() != 2 {
+		t.Fatalf("limit after two throttles = %d, want 2", g.current())
+	}
+	for range 2 {
+		_ = g.acquire(ctx)
+		g.release(false)
+	}
+	if g.current() != 3 {
+		t.Fatalf("limit after success run = %d, want 3", g.current())
+	}
+
+	fixed := newGate(4, false)
+	_ = fixed.acquire(ctx)
+	fixed.release(true)
+	if fixed.current() != 4 {
+		t.Fatalf("non-adaptive gate changed limit to %d", fixed.current())
+	}
+}
+
+func TestFetchManyAdaptsToRateLimiting(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	c.hc.SetRetryMax(1)
+	f := c.FetchMany(context.Background(), []int{1, 2, 3, 4, 5, 6, 7, 8}, FetchOptions{Concurrency: 8, Adaptive: true})
+	for r := range f.Results() {
+		var e *Error
+		if !errors.As(r.Err, &e) || e.Kind != ErrRateLimited {
+			t.Fatalf("book %d: expected ErrRateLimited, got %v", r.ID, r.Err)
+		}
+	}
+	if got := f.Concurrency(); got >= 8 {
+		t.Fatalf("concurrency after repeated 429s = %d, want it reduced", got)
+	}
+	if s := f.Summary(); s.Failed != 8 {
+		t.Fatalf("summary = %+v", s)
+	}
+}
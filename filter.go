@@ -0,0 +1,102 @@
+package gutendex
+
+import (
+	"context"
+	"strings"
+)
+
+// Filter is a predicate over books, for criteria the API cannot filter on.
+// Apply it to a listing with Iter.Filter and combine filters with And, Or
+// and Not.
+type Filter func(Book) bool
+
+// Filter returns an iterator over the values of it accepted by keep. Values
+// are tested as they are streamed, so pages are still fetched one at a
+// time and only as far as the caller iterates.
+func (it *Iter[T]) Filter(keep func(T) bool) *Iter[T] {
+	return newPullIter(func(ctx context.Context) ([]T, bool, error) {
+		it.ctx = ctx
+		for it.Next() {
+			if v := it.Value(); keep(v) {
+				return []T{v}, true, nil
+			}
+		}
+		return nil, false, it.Err()
+	})
+}
+
+// And accepts books accepted by every filter. With no filters it accepts
+// every book.
+func And(filters ...Filter) Filter {
+	return func(b Book) bool {
+		for _, f := range filters {
+			if !f(b) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or accepts books accepted by any filter. With no filters it accepts no
+// book.
+func Or(filters ...Filter) Filter {
+	return func(b Book) bool {
+		for _, f := range filters {
+			if f(b) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not accepts books rejected by f.
+func Not(f Filter) Filter {
+	return func(b Book) bool { return !f(b) }
+}
+
+// MinDownloads accepts books downloaded at least n times.
+func MinDownloads(n int) Filter {
+	return func(b Book) bool { return b.DownloadCount >= n }
+}
+
+// AuthorCount accepts books with between atLeast and atMost authors,
+// inclusive. A negative atMost means no upper bound.
+func AuthorCount(atLeast, atMost int) Filter {
+	return func(b Book) bool {
+		return len(b.Authors) >= atLeast && (atMost < 0 || len(b.Authors) <= atMost)
+	}
+}
+
+// HasFormat accepts books offered in a format whose MIME type starts with
+// prefix, e.g. "application/epub" or "text/html".
+func HasFormat(prefix string) Filter {
+	return func(b Book) bool { return matchMIME(b, prefix) }
+}
+
+// HasCover accepts books with a cover image, i.e. a CoverFormat entry in
+// Formats.
+func HasCover() Filter {
+	return func(b Book) bool { return b.Formats[CoverFormat] != "" }
+}
+
+// MediaType accepts books of the given media type, such as "Text", "Sound"
+// or "Image", ignoring case.
+func MediaType(t string) Filter {
+	return func(b Book) bool { return strings.EqualFold(b.MediaType, t) }
+}
+
+// OnBookshelf accepts books on the named bookshelf, ignoring case and any
+// "Browsing: " or "Category: " prefix on either side.
+func OnBookshelf(name string) Filter {
+	want := ShelfLabel(name)
+	return func(b Book) bool {
+		for _, s := range b.Bookshelves {
+			if strings.EqualFold(ShelfLabel(s), want) {
+				return true
+			}
+		}
+		return false
+	}
+}
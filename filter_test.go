@@ -0,0 +1,69 @@
+package gutendex
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestFilters(t *testing.T) {
+	books := testBooks()
+	books[0].MediaType = "Text"
+	books[1].MediaType = "Sound"
+	books[2].Formats["image/jpeg"] = "cover.jpg"
+	books[2].Bookshelves = []string{"Browsing: Fiction"}
+	books[2].Authors = append(books[2].Authors, Person{Name: "Translator, A"})
+	c := NewCatalog(books...)
+
+	tests := []struct {
+		name string
+		f    Filter
+		want []int
+	}{
+		{"min downloads", MinDownloads(80), []int{2, 3}},
+		{"media type", MediaType("sound"), []int{2}},
+		{"has format", HasFormat("application/epub"), []int{3}},
+		{"has cover", HasCover(), []int{3}},
+		{"bookshelf", OnBookshelf("fiction"), []int{3}},
+		{"author count", AuthorCount(2, -1), []int{3}},
+		{"and", And(MinDownloads(60), Not(HasCover())), []int{2}},
+		{"or", Or(MediaType("Text"), OnBookshelf("Best Books Ever Listings")), []int{2, 1}},
+		{"empty and", And(), []int{2, 3, 1}},
+		{"empty or", Or(), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collectIDs(t, c.ListBooks(Query{}).Filter(tt.f)); !slices.Equal(got, tt.want) {
+				t.Fatalf("ids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterStreamsPages(t *testing.T) {
+	requests := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		_, _ = fmt.Fprintf(w, `{"count":6,"next":"%s/books?page=%d","previous":null,"results":[{"id":%s1,"download_count":1},{"id":%s2,"download_count":100}]}`,
+			srv.URL, requests+1, page, page)
+	}))
+	defer srv.Close()
+
+	it := newTestClient(srv.URL).ListBooks(Query{}).Filter(MinDownloads(50))
+	if !it.Next() || it.Value().ID != 12 {
+		t.Fatalf("first match = %v, %v", it.Err(), it.Value())
+	}
+	if requests != 1 {
+		t.Fatalf("fetched %d pages for the first match", requests)
+	}
+	if !it.Next() || it.Value().ID != 22 || requests != 2 {
+		t.Fatalf("second match after %d pages", requests)
+	}
+}
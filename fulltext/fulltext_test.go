@@ -0,0 +1,258 @@
+package fulltext
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+var texts = map[int]string{
+	1342: "It is a truth universally acknowledged, that a single man in possession of a good fortune, must be in want of a wife. Mr. Darcy's pride and Elizabeth's prejudice.",
+	158:  "Emma Woodhouse, handsome, clever, and rich, with a comfortable home and happy disposition. She had a fortune of thirty thousand pounds.",
+	2701: "Call me Ishmael. The whale, the whale! Some years ago, never mind how long precisely, having little money in my purse, I thought I would sail about.",
+	84:   "You will rejoice to hear that no disaster has accompanied the commencement of an enterprise which you have regarded with such evil forebodings. The monster had no wife.",
+}
+
+func testIndex(t *testing.T) *Index {
+	t.Helper()
+	x := New()
+	for id, text := range texts {
+		if err := x.Add(id, strings.NewReader(text)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return x
+}
+
+func hitIDs(hits []Hit) []int {
+	ids := make([]int, len(hits))
+	for i, h := range hits {
+		ids[i] = h.ID
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	x := testIndex(t)
+	tests := []struct {
+		q    string
+		want []int
+	}{
+		{"fortune", []int{158, 1342}},
+		{"FORTUNE wife", []int{1342}},
+		{"fortune AND wife", []int{1342}},
+		{"wife OR whale", []int{2701, 84, 1342}},
+		{`"good fortune"`, []int{1342}},
+		{`"fortune good"`, nil},
+		{"fortune -wife", []int{158}},
+		{"fortune NOT wife", []int{158}},
+		{"(whale OR monster) AND NOT ishmael", []int{84}},
+		{"darcys", []int{1342}},
+		{"Darcy’s", []int{1342}},
+		{"-fortune -wife", []int{2701}},
+		{"", nil},
+		{"-- ...", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			hits, err := x.Search(tt.q, SearchOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hitIDs(hits); !slices.Equal(got, tt.want) && !(len(got) == 0 && len(tt.want) == 0) {
+				t.Errorf("Search(%q) = %v, want %v", tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchSyntaxErrors(t *testing.T) {
+	x := testIndex(t)
+	for _, q := range []string{`"unterminated`, "(whale", "whale)", "NOT", "whale OR )"} {
+		if _, err := x.Search(q, SearchOptions{}); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Search(%q) error = %v, want ErrSyntax", q, err)
+		}
+	}
+}
+
+func TestBM25Ranking(t *testing.T) {
+	x := New()
+	add := func(id int, text string) {
+		if err := x.Add(id, strings.NewReader(text)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	add(1, "whale whale whale sea ship")
+	add(2, "whale sea ship boat oar")
+	add(3, "whale "+strings.Repeat("filler ", 200))
+	add(4, "sea ship boat")
+	hits, err := x.Search("whale", SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hitIDs(hits), []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Fatalf("ranking = %v, want %v", got, want)
+	}
+	if !(hits[0].Score > hits[1].Score && hits[1].Score > hits[2].Score && hits[2].Score > 0) {
+		t.Errorf("scores = %v, %v, %v", hits[0].Score, hits[1].Score, hits[2].Score)
+	}
+	// A rarer word counts for more.
+	hits, err = x.Search("whale OR oar", SearchOptions{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].ID != 2 {
+		t.Errorf("whale OR oar = %v", hitIDs(hits))
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	x := testIndex(t)
+	hits, err := x.Search(`"single man" wife`, SearchOptions{SnippetWords: 8, Before: "<b>", After: "</b>"})
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("Search = %v, %v", hits, err)
+	}
+	want := "… acknowledged, that a <b>single</b> <b>man</b> in possession of …"
+	if hits[0].Snippet != want {
+		t.Errorf("snippet = %q, want %q", hits[0].Snippet, want)
+	}
+
+	x = New()
+	if err := x.Add(1, strings.NewReader("The\n\n  whale,   the whale!")); err != nil {
+		t.Fatal(err)
+	}
+	hits, err = x.Search("whale", SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "The **whale**, the **whale**"; hits[0].Snippet != want {
+		t.Errorf("snippet = %q, want %q", hits[0].Snippet, want)
+	}
+}
+
+func TestAddReplaceAndRemove(t *testing.T) {
+	x := testIndex(t)
+	if err := x.Add(2701, strings.NewReader("A different text about a fortune.")); err != nil {
+		t.Fatal(err)
+	}
+	if hits, _ := x.Search("whale", SearchOptions{}); len(hits) != 0 {
+		t.Errorf("replaced text still matches: %v", hitIDs(hits))
+	}
+	if hits, _ := x.Search("fortune", SearchOptions{}); len(hits) != 3 {
+		t.Errorf("fortune = %v", hitIDs(hits))
+	}
+	if !x.Remove(2701) || x.Remove(2701) || x.Has(2701) {
+		t.Fatal("Remove did not remove the text once")
+	}
+	if got, want := x.IDs(), []int{84, 158, 1342}; !slices.Equal(got, want) {
+		t.Errorf("IDs = %v, want %v", got, want)
+	}
+	if _, ok := x.postings["different"]; ok {
+		t.Error("postings of removed text left behind")
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	x := testIndex(t)
+	var buf bytes.Buffer
+	if err := x.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	y, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{"fortune", `"good fortune"`, "whale OR wife"} {
+		a, _ := x.Search(q, SearchOptions{})
+		b, _ := y.Search(q, SearchOptions{})
+		if !slices.Equal(a, b) {
+			t.Errorf("%q: decoded index = %v, want %v", q, b, a)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "books.idx")
+	z, err := Load(path)
+	if err != nil || z.Len() != 0 {
+		t.Fatalf("Load of missing file = %v, %v", z, err)
+	}
+	if err := x.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	z, err = Load(path)
+	if err != nil || z.Len() != len(texts) {
+		t.Fatalf("Load = %d texts, %v", z.Len(), err)
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	encode := func(docs ...fileDoc) *bytes.Buffer {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		enc := gob.NewEncoder(zw)
+		if err := enc.Encode(fileHeader{Version: fileVersion, Docs: len(docs)}); err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range docs {
+			if err := enc.Encode(d); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return &buf
+	}
+	good := fileDoc{ID: 1, Text: "call me", Spans: []int32{0, 4, 5, 7}}
+	if _, err := Decode(encode(good)); err != nil {
+		t.Fatalf("Decode of valid index: %v", err)
+	}
+	tests := map[string][]fileDoc{
+		"odd":        {{ID: 1, Text: "call me", Spans: []int32{0, 4, 5}}},
+		"past end":   {{ID: 1, Text: "call me", Spans: []int32{0, 4, 5, 9}}},
+		"reversed":   {{ID: 1, Text: "call me", Spans: []int32{4, 0}}},
+		"negative":   {{ID: 1, Text: "call me", Spans: []int32{-1, 4}}},
+		"overlap":    {{ID: 1, Text: "call me", Spans: []int32{0, 4, 2, 7}}},
+		"duplicated": {good, good},
+	}
+	for name, docs := range tests {
+		if _, err := Decode(encode(docs...)); err == nil {
+			t.Errorf("%s: Decode succeeded", name)
+		}
+	}
+}
+
+func TestAddBooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, "Header\n*** START OF THE PROJECT GUTENBERG EBOOK ***\nThe whale.\n*** END OF THE PROJECT GUTENBERG EBOOK ***\nlicense\n")
+	}))
+	defer srv.Close()
+
+	x := New()
+	books := []gutendex.Book{
+		{ID: 2701, Formats: map[string]string{"text/plain; charset=utf-8": srv.URL + "/2701.txt"}},
+		{ID: 1, Formats: map[string]string{"application/epub+zip": srv.URL + "/1.epub"}},
+	}
+	if err := x.AddBooks(context.Background(), gutendex.NewClient(), books...); err != nil {
+		t.Fatal(err)
+	}
+	if got := x.IDs(); !slices.Equal(got, []int{2701}) {
+		t.Fatalf("IDs = %v", got)
+	}
+	for _, q := range []string{"whale", "license", "header"} {
+		hits, _ := x.Search(q, SearchOptions{})
+		if (len(hits) == 1) != (q == "whale") {
+			t.Errorf("Search(%q) = %v", q, hitIDs(hits))
+		}
+	}
+}
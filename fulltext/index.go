@@ -0,0 +1,203 @@
+// Package fulltext searches inside the texts of books, which the Gutendex
+// API does not index.
+//
+// An Index holds the plain texts of books keyed by Book.ID together with
+// an inverted index of their words. Queries combine words and quoted
+// phrases with AND, OR and NOT; matches are ranked with BM25 and returned
+// with highlighted snippets. An index is saved to and loaded from a single
+// file and updated in place as further books are downloaded.
+package fulltext
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// Index is an inverted index of book texts. It is safe for concurrent use.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[int]*document
+	postings map[string]map[int][]int32
+	totalLen int
+}
+
+// document is an indexed text. Spans holds the start and end byte offsets
+// of each of its tokens, in order.
+type document struct {
+	Text  string
+	Spans []int32
+}
+
+func (d *document) len() int { return len(d.Spans) / 2 }
+
+// New returns an empty index.
+func New() *Index {
+	return &Index{docs: make(map[int]*document), postings: make(map[string]map[int][]int32)}
+}
+
+// Add indexes the text read from r as the book id, replacing any text
+// previously indexed for it. The text should be stripped of the Project
+// Gutenberg header and license, e.g. with gutendex.StripBoilerplate.
+func (x *Index) Add(id int, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(b) > math.MaxInt32 {
+		return fmt.Errorf("fulltext: text of book %d too large", id)
+	}
+	doc := &document{Text: string(b)}
+	for _, t := range tokenize(doc.Text) {
+		doc.Spans = append(doc.Spans, int32(t.start), int32(t.end))
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(id)
+	x.insert(id, doc)
+	return nil
+}
+
+// AddBooks downloads and indexes the plain text of each book not yet in
+// the index, stripping the Project Gutenberg boilerplate. Books without a
+// plain-text format are skipped. It stops at the first error.
+func (x *Index) AddBooks(ctx context.Context, c *gutendex.Client, books ...gutendex.Book) error {
+	for _, b := range books {
+		if x.Has(b.ID) || b.TextURL() == "" {
+			continue
+		}
+		rc, err := c.OpenText(ctx, b)
+		if err != nil {
+			return err
+		}
+		err = x.Add(b.ID, gutendex.StripBoilerplate(rc))
+		if cerr := rc.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove removes the text of book id and reports whether it was indexed.
+func (x *Index) Remove(id int) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.remove(id)
+}
+
+func (x *Index) remove(id int) bool {
+	doc, ok := x.docs[id]
+	if !ok {
+		return false
+	}
+	for i := range doc.len() {
+		term := termAt(doc, i)
+		if p := x.postings[term]; p != nil {
+			delete(p, id)
+			if len(p) == 0 {
+				delete(x.postings, term)
+			}
+		}
+	}
+	x.totalLen -= doc.len()
+	delete(x.docs, id)
+	return true
+}
+
+// Has reports whether the text of book id is indexed.
+func (x *Index) Has(id int) bool {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	_, ok := x.docs[id]
+	return ok
+}
+
+// Len returns the number of indexed texts.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.docs)
+}
+
+// IDs returns the IDs of the indexed books in increasing order.
+func (x *Index) IDs() []int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.idsLocked()
+}
+
+func (x *Index) idsLocked() []int {
+	ids := make([]int, 0, len(x.docs))
+	for id := range x.docs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// token is a word of a text, given by its byte offsets.
+type token struct{ start, end int }
+
+// tokenize splits s into words: runs of letters and digits, including
+// apostrophes between letters, as in "Austen's".
+func tokenize(s string) []token {
+	var out []token
+	start := -1
+	for i, r := range s {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if start < 0 {
+				start = i
+			}
+		case isApostrophe(r) && start >= 0 && nextIsLetter(s[i+utf8.RuneLen(r):]):
+			// Keep the word going.
+		case start >= 0:
+			out = append(out, token{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		out = append(out, token{start, len(s)})
+	}
+	return out
+}
+
+// normalize returns the term indexed for a word found by tokenize: the
+// word lower-cased without apostrophes, so "Austen's" yields "austens".
+func normalize(word string) string {
+	var b strings.Builder
+	for _, r := range word {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+func isApostrophe(r rune) bool { return r == '\'' || r == '’' }
+
+func nextIsLetter(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLetter(r)
+}
+
+// terms returns the terms of the words of s.
+func terms(s string) []string {
+	toks := tokenize(s)
+	out := make([]string, len(toks))
+	for i, t := range toks {
+		out[i] = normalize(s[t.start:t.end])
+	}
+	return out
+}
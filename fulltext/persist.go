@@ -0,0 +1,156 @@
+package fulltext
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// fileVersion identifies the encoding written by Encode.
+const fileVersion = 1
+
+// fileHeader precedes the documents in an encoded index.
+type fileHeader struct {
+	Version int
+	Docs    int
+}
+
+// fileDoc is an encoded document. Postings are rebuilt from the spans, so
+// only the text and token offsets are stored.
+type fileDoc struct {
+	ID    int
+	Text  string
+	Spans []int32
+}
+
+// Encode writes the index to w in a compressed binary format read by
+// Decode.
+func (x *Index) Encode(w io.Writer) error {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	zw := gzip.NewWriter(w)
+	enc := gob.NewEncoder(zw)
+	if err := enc.Encode(fileHeader{Version: fileVersion, Docs: len(x.docs)}); err != nil {
+		return fmt.Errorf("fulltext: encode: %w", err)
+	}
+	for _, id := range x.idsLocked() {
+		d := x.docs[id]
+		if err := enc.Encode(fileDoc{ID: id, Text: d.Text, Spans: d.Spans}); err != nil {
+			return fmt.Errorf("fulltext: encode: %w", err)
+		}
+	}
+	return zw.Close()
+}
+
+// Decode reads an index written by Encode.
+func Decode(r io.Reader) (*Index, error) {
+	zr, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("fulltext: decode: %w", err)
+	}
+	dec := gob.NewDecoder(zr)
+	var h fileHeader
+	if err := dec.Decode(&h); err != nil {
+		return nil, fmt.Errorf("fulltext: decode: %w", err)
+	}
+	if h.Version != fileVersion {
+		return nil, fmt.Errorf("fulltext: unsupported index version %d", h.Version)
+	}
+	x := New()
+	for range h.Docs {
+		var d fileDoc
+		if err := dec.Decode(&d); err != nil {
+			return nil, fmt.Errorf("fulltext: decode: %w", err)
+		}
+		if _, dup := x.docs[d.ID]; dup || !validSpans(d.Spans, len(d.Text)) {
+			return nil, fmt.Errorf("fulltext: decode: malformed document %d", d.ID)
+		}
+		x.insert(d.ID, &document{Text: d.Text, Spans: d.Spans})
+	}
+	return x, nil
+}
+
+// validSpans reports whether spans holds pairs of increasing,
+// non-overlapping byte offsets within a text of length n.
+func validSpans(spans []int32, n int) bool {
+	if len(spans)%2 != 0 {
+		return false
+	}
+	prev := int32(0)
+	for i := 0; i < len(spans); i += 2 {
+		start, end := spans[i], spans[i+1]
+		if start < prev || end < start || int(end) > n {
+			return false
+		}
+		prev = end
+	}
+	return true
+}
+
+// insert adds doc, whose spans are already known, rebuilding its
+// postings from the spans.
+func (x *Index) insert(id int, doc *document) {
+	x.docs[id] = doc
+	x.totalLen += doc.len()
+	for i := range doc.len() {
+		term := termAt(doc, i)
+		p := x.postings[term]
+		if p == nil {
+			p = make(map[int][]int32)
+			x.postings[term] = p
+		}
+		p[id] = append(p[id], int32(i))
+	}
+}
+
+// termAt returns the term of token i of doc.
+func termAt(doc *document, i int) string {
+	return normalize(doc.Text[doc.Spans[2*i]:doc.Spans[2*i+1]])
+}
+
+// Save writes the index to the file at path, replacing it atomically.
+func (x *Index) Save(path string) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	err = x.Encode(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads the index saved at path. A missing file yields an empty
+// index, so that an index can be built up across runs:
+//
+//	x, err := fulltext.Load("books.idx")
+//	...
+//	err = x.AddBooks(ctx, client, newBooks...)
+//	...
+//	err = x.Save("books.idx")
+func Load(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return Decode(f)
+}
@@ -0,0 +1,257 @@
+package fulltext
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrSyntax is wrapped by the errors Search returns for malformed queries.
+var ErrSyntax = errors.New("fulltext: invalid query")
+
+// A query is parsed into a tree of nodes. Words and phrases are leaves;
+// a single word is a phrase of one term.
+type node interface{}
+
+type phraseNode struct{ terms []string }
+
+type andNode struct{ kids []node }
+
+type orNode struct{ kids []node }
+
+type notNode struct{ kid node }
+
+// item is a lexical element of a query.
+type item struct {
+	kind itemKind
+	text string
+}
+
+type itemKind int
+
+const (
+	itemWord itemKind = iota
+	itemPhrase
+	itemAnd
+	itemOr
+	itemNot
+	itemLeft
+	itemRight
+)
+
+// lex splits a query into items. A '-' before a word, phrase or
+// parenthesis negates it.
+func lex(q string) ([]item, error) {
+	var items []item
+	rs := []rune(q)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			items = append(items, item{kind: itemLeft})
+			i++
+		case r == ')':
+			items = append(items, item{kind: itemRight})
+			i++
+		case r == '-' && i+1 < len(rs) && !unicode.IsSpace(rs[i+1]) && rs[i+1] != ')':
+			items = append(items, item{kind: itemNot})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(rs) && rs[end] != '"' {
+				end++
+			}
+			if end == len(rs) {
+				return nil, fmt.Errorf("%w: unterminated phrase", ErrSyntax)
+			}
+			items = append(items, item{kind: itemPhrase, text: string(rs[i+1 : end])})
+			i = end + 1
+		default:
+			end := i
+			for end < len(rs) && !unicode.IsSpace(rs[end]) && !strings.ContainsRune(`()"`, rs[end]) {
+				end++
+			}
+			w := string(rs[i:end])
+			switch w {
+			case "AND":
+				items = append(items, item{kind: itemAnd})
+			case "OR":
+				items = append(items, item{kind: itemOr})
+			case "NOT":
+				items = append(items, item{kind: itemNot})
+			default:
+				items = append(items, item{kind: itemWord, text: w})
+			}
+			i = end
+		}
+	}
+	return items, nil
+}
+
+// parser is a recursive-descent parser of the grammar
+//
+//	or      = and { "OR" and }
+//	and     = unary { [ "AND" ] unary }
+//	unary   = "NOT" unary | primary
+//	primary = word | phrase | "(" or ")"
+type parser struct {
+	items []item
+	pos   int
+}
+
+// parse parses q. It returns a nil node for a query without words.
+func parse(q string) (node, error) {
+	items, err := lex(q)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{items: items}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.items) {
+		return nil, fmt.Errorf("%w: unexpected %s", ErrSyntax, p.items[p.pos].describe())
+	}
+	return n, nil
+}
+
+func (p *parser) peek() (item, bool) {
+	if p.pos < len(p.items) {
+		return p.items[p.pos], true
+	}
+	return item{}, false
+}
+
+func (p *parser) or() (node, error) {
+	var kids []node
+	for {
+		n, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		if n != nil {
+			kids = append(kids, n)
+		}
+		if it, ok := p.peek(); !ok || it.kind != itemOr {
+			break
+		}
+		p.pos++
+	}
+	switch len(kids) {
+	case 0:
+		return nil, nil
+	case 1:
+		return kids[0], nil
+	}
+	return &orNode{kids: kids}, nil
+}
+
+func (p *parser) and() (node, error) {
+	var kids []node
+	for {
+		it, ok := p.peek()
+		if !ok || it.kind == itemOr || it.kind == itemRight {
+			break
+		}
+		if it.kind == itemAnd {
+			p.pos++
+			continue
+		}
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if n != nil {
+			kids = append(kids, n)
+		}
+	}
+	switch len(kids) {
+	case 0:
+		return nil, nil
+	case 1:
+		return kids[0], nil
+	}
+	return &andNode{kids: kids}, nil
+}
+
+func (p *parser) unary() (node, error) {
+	it, _ := p.peek()
+	if it.kind != itemNot {
+		return p.primary()
+	}
+	p.pos++
+	if _, ok := p.peek(); !ok {
+		return nil, fmt.Errorf("%w: NOT without operand", ErrSyntax)
+	}
+	n, err := p.unary()
+	if err != nil || n == nil {
+		return nil, err
+	}
+	return &notNode{kid: n}, nil
+}
+
+func (p *parser) primary() (node, error) {
+	it, _ := p.peek()
+	p.pos++
+	switch it.kind {
+	case itemWord, itemPhrase:
+		// Words such as "well-known" are searched as phrases; punctuation
+		// alone matches nothing and is dropped.
+		ts := terms(it.text)
+		if len(ts) == 0 {
+			return nil, nil
+		}
+		return &phraseNode{terms: ts}, nil
+	case itemLeft:
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if it, ok := p.peek(); !ok || it.kind != itemRight {
+			return nil, fmt.Errorf("%w: missing )", ErrSyntax)
+		}
+		p.pos++
+		return n, nil
+	}
+	return nil, fmt.Errorf("%w: unexpected %s", ErrSyntax, it.describe())
+}
+
+func (it item) describe() string {
+	switch it.kind {
+	case itemAnd:
+		return "AND"
+	case itemOr:
+		return "OR"
+	case itemNot:
+		return "NOT"
+	case itemRight:
+		return ")"
+	}
+	return fmt.Sprintf("%q", it.text)
+}
+
+// leaves returns the phrases of n that are not negated, which are the
+// ones that contribute to scores and snippets.
+func leaves(n node) []*phraseNode {
+	switch n := n.(type) {
+	case *phraseNode:
+		return []*phraseNode{n}
+	case *andNode:
+		var out []*phraseNode
+		for _, k := range n.kids {
+			out = append(out, leaves(k)...)
+		}
+		return out
+	case *orNode:
+		var out []*phraseNode
+		for _, k := range n.kids {
+			out = append(out, leaves(k)...)
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package fulltext
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// BM25 parameters: term frequency saturation and length normalization.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// DefaultLimit is the number of hits Search returns when
+// SearchOptions.Limit is zero.
+const DefaultLimit = 10
+
+// DefaultSnippetWords is the length of snippets in words when
+// SearchOptions.SnippetWords is zero.
+const DefaultSnippetWords = 30
+
+// SearchOptions configures Search.
+type SearchOptions struct {
+	// Limit bounds the number of hits; a negative limit returns all.
+	Limit int
+	// SnippetWords is the length of each snippet in words.
+	SnippetWords int
+	// Before and After enclose matched words in snippets. They default to
+	// "**".
+	Before, After string
+}
+
+// Hit is a book matching a query.
+type Hit struct {
+	ID    int
+	Score float64
+	// Snippet is the passage of the text with the most matches, with the
+	// matched words enclosed in SearchOptions.Before and After.
+	Snippet string
+}
+
+// Search returns the books matching q, best first, ties broken by ID.
+//
+// Words in q must all occur in a text, in any order. Quoted phrases must
+// occur as written, ignoring case and punctuation. AND is implied between
+// words and may be written out; OR matches either side and binds less
+// tightly than AND. NOT or a leading '-' excludes matches, and
+// parentheses group:
+//
+//	darcy "pride and prejudice" -zombies
+//	(whale OR leviathan) AND NOT "moby dick"
+//
+// Matches are ranked by BM25 over the words and phrases that are not
+// negated. A query of only negations matches every other text, unranked.
+// Malformed queries report an error wrapping ErrSyntax.
+func (x *Index) Search(q string, opts SearchOptions) ([]Hit, error) {
+	n, err := parse(q)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return nil, nil
+	}
+	if opts.Limit == 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.SnippetWords <= 0 {
+		opts.SnippetWords = DefaultSnippetWords
+	}
+	if opts.Before == "" && opts.After == "" {
+		opts.Before, opts.After = "**", "**"
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	s := &search{x: x, matches: make(map[*phraseNode]map[int][]int32)}
+	docs := s.eval(n)
+	pos := leaves(n)
+	hits := make([]Hit, 0, len(docs))
+	for id := range docs {
+		hits = append(hits, Hit{ID: id, Score: s.score(id, pos)})
+	}
+	slices.SortFunc(hits, func(a, b Hit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	if opts.Limit > 0 && len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+	}
+	for i := range hits {
+		hits[i].Snippet = s.snippet(hits[i].ID, pos, opts)
+	}
+	return hits, nil
+}
+
+// search evaluates one query, caching the matches of each phrase.
+type search struct {
+	x       *Index
+	matches map[*phraseNode]map[int][]int32
+}
+
+type docSet map[int]bool
+
+func (s *search) eval(n node) docSet {
+	switch n := n.(type) {
+	case *phraseNode:
+		out := make(docSet)
+		for id := range s.phrase(n) {
+			out[id] = true
+		}
+		return out
+	case *orNode:
+		out := make(docSet)
+		for _, k := range n.kids {
+			for id := range s.eval(k) {
+				out[id] = true
+			}
+		}
+		return out
+	case *andNode:
+		var out docSet
+		var excluded []node
+		for _, k := range n.kids {
+			if not, ok := k.(*notNode); ok {
+				excluded = append(excluded, not.kid)
+				continue
+			}
+			set := s.eval(k)
+			if out == nil {
+				out = set
+				continue
+			}
+			for id := range out {
+				if !set[id] {
+					delete(out, id)
+				}
+			}
+		}
+		if out == nil {
+			out = s.all()
+		}
+		for _, k := range excluded {
+			for id := range s.eval(k) {
+				delete(out, id)
+			}
+		}
+		return out
+	case *notNode:
+		out := s.all()
+		for id := range s.eval(n.kid) {
+			delete(out, id)
+		}
+		return out
+	}
+	return nil
+}
+
+func (s *search) all() docSet {
+	out := make(docSet, len(s.x.docs))
+	for id := range s.x.docs {
+		out[id] = true
+	}
+	return out
+}
+
+// phrase returns the start positions of n in each text containing it.
+func (s *search) phrase(n *phraseNode) map[int][]int32 {
+	if m, ok := s.matches[n]; ok {
+		return m
+	}
+	m := make(map[int][]int32)
+	first := s.x.postings[n.terms[0]]
+	for id, starts := range first {
+		if len(n.terms) == 1 {
+			m[id] = starts
+			continue
+		}
+		var found []int32
+	next:
+		for _, p := range starts {
+			for k, t := range n.terms[1:] {
+				if _, ok := slices.BinarySearch(s.x.postings[t][id], p+int32(k)+1); !ok {
+					continue next
+				}
+			}
+			found = append(found, p)
+		}
+		if len(found) > 0 {
+			m[id] = found
+		}
+	}
+	s.matches[n] = m
+	return m
+}
+
+// score returns the BM25 score of text id for the phrases pos, treating
+// each phrase as a term.
+func (s *search) score(id int, pos []*phraseNode) float64 {
+	n := float64(len(s.x.docs))
+	avg := float64(s.x.totalLen) / max(n, 1)
+	dl := float64(s.x.docs[id].len())
+	var score float64
+	for _, p := range pos {
+		m := s.phrase(p)
+		tf := float64(len(m[id]))
+		if tf == 0 {
+			continue
+		}
+		df := float64(len(m))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		norm := 1 - bm25B
+		if avg > 0 {
+			norm += bm25B * dl / avg
+		}
+		score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+	}
+	return score
+}
+
+// snippet returns the window of opts.SnippetWords words of text id that
+// contains the most matches of pos, with the matched words highlighted.
+func (s *search) snippet(id int, pos []*phraseNode, opts SearchOptions) string {
+	doc := s.x.docs[id]
+	total := doc.len()
+	if total == 0 {
+		return ""
+	}
+	marked := make(map[int32]bool)
+	var starts []int32
+	for _, p := range pos {
+		for _, start := range s.phrase(p)[id] {
+			starts = append(starts, start)
+			for k := range len(p.terms) {
+				marked[start+int32(k)] = true
+			}
+		}
+	}
+	slices.Sort(starts)
+
+	width := min(opts.SnippetWords, total)
+	from := 0
+	if len(starts) > 0 {
+		// Find the window holding the most phrase starts, then center it
+		// on them.
+		best, bestFirst, bestLast := 0, 0, 0
+		for i, j := 0, 0; i < len(starts); i++ {
+			for j < len(starts) && int(starts[j]-starts[i]) < width {
+				j++
+			}
+			if j-i > best {
+				best, bestFirst, bestLast = j-i, int(starts[i]), int(starts[j-1])
+			}
+		}
+		from = bestFirst - (width-(bestLast-bestFirst+1))/2
+		from = max(0, min(from, total-width))
+	}
+	to := from + width - 1
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("… ")
+	}
+	last := int(doc.Spans[2*from])
+	for i := from; i <= to; i++ {
+		start, end := int(doc.Spans[2*i]), int(doc.Spans[2*i+1])
+		writeSpace(&b, doc.Text[last:start])
+		if marked[int32(i)] {
+			b.WriteString(opts.Before)
+			b.WriteString(doc.Text[start:end])
+			b.WriteString(opts.After)
+		} else {
+			b.WriteString(doc.Text[start:end])
+		}
+		last = end
+	}
+	if to < total-1 {
+		b.WriteString(" …")
+	}
+	return b.String()
+}
+
+// writeSpace writes the text between two words with runs of white space
+// collapsed to one space.
+func writeSpace(b *strings.Builder, gap string) {
+	space := false
+	for _, r := range gap {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	if space && b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+}
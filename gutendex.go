@@ -3,11 +3,19 @@ package gutendex
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
 	internal "github.com/alex-rs/go-gutendex/internal"
+	"github.com/alex-rs/go-gutendex/language"
+	"golang.org/x/time/rate"
 )
 
 // Person represents an individual in Gutendex responses.
@@ -32,22 +40,65 @@ type Book struct {
 	DownloadCount int               `json:"download_count"`
 }
 
+// CoverFormat is the Formats key of a book's cover image. Gutendex lists
+// covers only as JPEG.
+const CoverFormat = "image/jpeg"
+
+var _ BookSource = (*Client)(nil)
+
+// LanguageNames returns the English names of the book's languages, e.g.
+// "French" for "fr". Unknown codes are returned unchanged.
+func (b Book) LanguageNames() []string {
+	names := make([]string, len(b.Languages))
+	for i, code := range b.Languages {
+		names[i] = language.Name(code)
+	}
+	return names
+}
+
+// clone returns a copy of b that shares no slices or maps with it.
+func (b Book) clone() Book {
+	b.Authors = slices.Clone(b.Authors)
+	b.Translators = slices.Clone(b.Translators)
+	b.Subjects = slices.Clone(b.Subjects)
+	b.Bookshelves = slices.Clone(b.Bookshelves)
+	b.Languages = slices.Clone(b.Languages)
+	b.Formats = maps.Clone(b.Formats)
+	return b
+}
+
 // Client provides access to the Gutendex API.
 type Client struct {
 	hc      *internal.Client
 	baseURL string
+	log     internal.LogConfig
 }
 
 // NewClient constructs a new API client.
-func NewClient() *Client {
-	return &Client{
+func NewClient(opts ...Option) *Client {
+	c := &Client{
 		hc:      internal.New(),
 		baseURL: "https://gutendex.com",
+		log:     internal.LogConfig{RequestLevel: slog.LevelInfo, AttemptLevel: slog.LevelDebug},
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	if c.log.Logger != nil {
+		c.hc.SetLogger(c.log)
+	}
+	return c
 }
 
-// ListBooks returns an iterator over books matching the query.
+// RateLimit reports the current request rate limit in requests per second.
+func (c *Client) RateLimit() rate.Limit { return c.hc.Rate() }
+
+// ListBooks returns an iterator over books matching the query. An invalid
+// query yields no books and an ErrInvalidQuery error from Err.
 func (c *Client) ListBooks(q Query) *Iter[Book] {
+	if err := q.Validate(); err != nil {
+		return newErrIter[Book](err)
+	}
 	u, _ := url.Parse(c.baseURL + "/books")
 	vals := q.Values()
 	if len(vals) > 0 {
@@ -56,22 +107,85 @@ func (c *Client) ListBooks(q Query) *Iter[Book] {
 	return NewIter[Book](c.hc, u.String())
 }
 
+// GetPage retrieves one page of the listing for q, numbered from 1. It
+// suits callers that render pages themselves, such as feed generators;
+// use ListBooks to walk all results.
+func (c *Client) GetPage(ctx context.Context, q Query, page int) (*Page[Book], error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	vals := q.Values()
+	if page > 1 {
+		vals.Set("page", strconv.Itoa(page))
+	}
+	u, _ := url.Parse(c.baseURL + "/books")
+	u.RawQuery = vals.Encode()
+	var p Page[Book]
+	if err := c.getJSON(ctx, "gutendex.GetPage", u.String(), &p, internal.Attribute{Key: "gutendex.page", Value: page}); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
 // GetBook retrieves a single book by ID.
 func (c *Client) GetBook(ctx context.Context, id int) (*Book, error) {
-	var b Book
 	url := fmt.Sprintf("%s/books/%d", c.baseURL, id)
-	if err := c.getJSON(ctx, url, &b); err != nil {
-		return nil, err
+	v, err := c.hc.Coalesce(ctx, "book "+url, func(ctx context.Context) (any, error) {
+		var b Book
+		if err := c.getJSON(ctx, "gutendex.GetBook", url, &b, internal.Attribute{Key: "gutendex.book_id", Value: id}); err != nil {
+			return nil, err
+		}
+		return &b, nil
+	})
+	if err != nil {
+		return nil, coalesceError("GetBook", err)
 	}
+	b := v.(*Book).clone()
 	return &b, nil
 }
 
+// GetBooks retrieves the books with the given IDs in a single listing request.
+// Books are returned in the order of ids; IDs unknown to Gutendex are omitted.
+func (c *Client) GetBooks(ctx context.Context, ids []int) ([]Book, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	u, _ := url.Parse(c.baseURL + "/books")
+	u.RawQuery = url.Values{"ids": {strings.Join(parts, ",")}}.Encode()
+
+	ctx, span := c.hc.StartSpan(ctx, "gutendex.GetBooks", internal.Attribute{Key: "gutendex.book_count", Value: len(ids)})
+	defer span.End()
+	it := NewIter[Book](c.hc, u.String())
+	it.ctx = ctx
+	var found []Book
+	for it.Next() {
+		found = append(found, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return orderByIDs(found, ids), nil
+}
+
 // Search is a convenience wrapper performing a keyword author search.
 func (c *Client) Search(keyword string) *Iter[Book] {
 	return c.ListBooks(Query{Author: keyword})
 }
 
-func (c *Client) getJSON(ctx context.Context, url string, dst any) error {
+// getJSON fetches url into dst inside a span named op.
+func (c *Client) getJSON(ctx context.Context, op, url string, dst any, attrs ...internal.Attribute) (err error) {
+	ctx, span := c.hc.StartSpan(ctx, op, attrs...)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return &Error{Op: "getJSON", Kind: ErrNetwork, Err: err}
@@ -81,6 +195,10 @@ func (c *Client) getJSON(ctx context.Context, url string, dst any) error {
 		return &Error{Op: "getJSON", Kind: ErrNetwork, Err: err}
 	}
 	defer func() { _ = resp.Body.Close() }()
+	span.SetAttributes(
+		internal.Attribute{Key: "http.status_code", Value: resp.StatusCode},
+		internal.Attribute{Key: "gutendex.cache", Value: cacheStatus(resp)},
+	)
 	if resp.StatusCode != http.StatusOK {
 		kind := ErrServer
 		switch resp.StatusCode {
@@ -92,7 +210,25 @@ func (c *Client) getJSON(ctx context.Context, url string, dst any) error {
 		return &Error{Op: "getJSON", Kind: kind, Err: fmt.Errorf("status %d", resp.StatusCode)}
 	}
 	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		c.hc.ReportDecodeError("getJSON", err)
 		return &Error{Op: "getJSON", Kind: ErrServer, Err: err}
 	}
 	return nil
 }
+
+// coalesceError wraps the context error returned to a caller that stopped
+// waiting on a shared request.
+func coalesceError(op string, err error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+	return &Error{Op: op, Kind: ErrNetwork, Err: err}
+}
+
+func cacheStatus(resp *http.Response) string {
+	if resp.Header.Get("X-From-Cache") == "1" {
+		return "hit"
+	}
+	return "miss"
+}
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	internal "github.com/alex-rs/go-gutendex/internal"
 	"golang.org/x/time/rate"
@@ -226,3 +229,102 @@ func TestValueBeforeNextPanics(t *testing.T) {
 		_ = it.Value()
 	}) // expect panic
 }
+
+func TestGetBookCoalescesConcurrentCalls(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = fmt.Fprint(w, `{"id":1,"title":"shared","subjects":["a"]}`)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	const callers = 8
+	var wg sync.WaitGroup
+	books := make([]*Book, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			books[i], errs[i] = c.GetBook(context.Background(), 1)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if hits.Load() != 1 {
+		t.Fatalf("expected 1 upstream request, got %d", hits.Load())
+	}
+	for i := range callers {
+		if errs[i] != nil || books[i].Title != "shared" {
+			t.Fatalf("caller %d: %+v, %v", i, books[i], errs[i])
+		}
+	}
+	books[0].Subjects[0] = "mutated"
+	if books[1].Subjects[0] != "a" {
+		t.Fatalf("callers must not share slices")
+	}
+}
+
+func TestIterCoalescedPagesNotShared(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = fmt.Fprint(w, `{"count":1,"next":null,"previous":null,"results":[{"id":1,"subjects":["a"],"formats":{"text/plain":"u"}}]}`)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	const callers = 4
+	var wg sync.WaitGroup
+	books := make([]Book, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			it := c.ListBooks(Query{})
+			if it.Next() {
+				books[i] = it.Value()
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if hits.Load() != 1 {
+		t.Fatalf("expected 1 upstream request, got %d", hits.Load())
+	}
+	books[0].Subjects[0] = "mutated"
+	books[0].Formats["text/plain"] = "mutated"
+	for _, b := range books[1:] {
+		if b.Subjects[0] != "a" || b.Formats["text/plain"] != "u" {
+			t.Fatalf("iterators must not share slices or maps: %+v", b)
+		}
+	}
+}
+
+func TestGetBookCanceledWaiter(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = fmt.Fprint(w, `{"id":1}`)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := newTestClient(srv.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.GetBook(ctx, 1)
+	var e *Error
+	if !errors.As(err, &e) || e.Kind != ErrNetwork || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected wrapped deadline error, got %v", err)
+	}
+}
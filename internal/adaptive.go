@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// AdaptiveConfig configures additive-increase/multiplicative-decrease
+// control of the request rate.
+type AdaptiveConfig struct {
+	// Min and Max bound the rate in requests per second. A Min that is
+	// not positive, which would let throttling stop requests altogether,
+	// defaults to DefaultMinRate.
+	Min, Max rate.Limit
+	// Increase is added to the rate after every successful response.
+	// Defaults to a tenth of Min.
+	Increase rate.Limit
+	// Decrease multiplies the rate on throttling. Defaults to 0.5.
+	Decrease float64
+	// LatencyFactor treats a response as throttling when its latency
+	// exceeds the moving average by this factor. Defaults to 3; negative
+	// disables latency tracking.
+	LatencyFactor float64
+}
+
+// DefaultMinRate is the lowest rate an adaptive limiter falls to unless
+// configured otherwise: one request every ten seconds.
+const DefaultMinRate rate.Limit = 0.1
+
+// latencyWarmup is the number of samples averaged before latency is used
+// as a congestion signal.
+const latencyWarmup = 5
+
+type adaptiveRate struct {
+	cfg AdaptiveConfig
+
+	mu      sync.Mutex
+	avg     time.Duration
+	samples int
+}
+
+// SetAdaptiveRate lets the limiter rate follow server responses: it grows
+// on sustained success and is cut on 429 or 503 responses or when latency
+// rises sharply. The current rate is clamped into the configured bounds.
+func (c *Client) SetAdaptiveRate(cfg AdaptiveConfig) {
+	if cfg.Max < cfg.Min {
+		cfg.Min, cfg.Max = cfg.Max, cfg.Min
+	}
+	if cfg.Min <= 0 {
+		cfg.Min = DefaultMinRate
+	}
+	cfg.Max = max(cfg.Max, cfg.Min)
+	if cfg.Increase <= 0 {
+		cfg.Increase = cfg.Min / 10
+	}
+	if cfg.Decrease <= 0 || cfg.Decrease >= 1 {
+		cfg.Decrease = 0.5
+	}
+	if cfg.LatencyFactor == 0 {
+		cfg.LatencyFactor = 3
+	}
+	c.adaptive = &adaptiveRate{cfg: cfg}
+	c.Limiter.SetLimit(clamp(c.Limiter.Limit(), cfg.Min, cfg.Max))
+}
+
+// Rate reports the current limiter rate in requests per second.
+func (c *Client) Rate() rate.Limit { return c.Limiter.Limit() }
+
+// observe adjusts l after an attempt that produced resp in d.
+func (a *adaptiveRate) observe(l *rate.Limiter, resp *http.Response, d time.Duration) {
+	if resp.Header.Get("X-From-Cache") == "1" {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	throttled := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+	if !throttled && a.cfg.LatencyFactor > 0 && a.samples >= latencyWarmup &&
+		float64(d) > a.cfg.LatencyFactor*float64(a.avg) {
+		throttled = true
+	}
+	if a.samples == 0 {
+		a.avg = d
+	} else {
+		a.avg += (d - a.avg) / 5
+	}
+	a.samples++
+
+	cur := l.Limit()
+	if throttled {
+		cur = rate.Limit(float64(cur) * a.cfg.Decrease)
+	} else if resp.StatusCode < 500 {
+		cur += a.cfg.Increase
+	}
+	l.SetLimit(clamp(cur, a.cfg.Min, a.cfg.Max))
+}
+
+func clamp(r, lo, hi rate.Limit) rate.Limit {
+	return max(lo, min(r, hi))
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func response(status int) *http.Response {
+	return &http.Response{StatusCode: status, Header: make(http.Header)}
+}
+
+func TestAdaptiveRateAIMD(t *testing.T) {
+	c := New()
+	c.SetAdaptiveRate(AdaptiveConfig{Min: 1, Max: 4, Increase: 0.5, LatencyFactor: -1})
+	a := c.adaptive
+
+	a.observe(c.Limiter, response(http.StatusOK), time.Millisecond)
+	a.observe(c.Limiter, response(http.StatusOK), time.Millisecond)
+	if got := c.Rate(); got != 2 {
+		t.Fatalf("rate after two successes = %v, want 2", got)
+	}
+	for range 10 {
+		a.observe(c.Limiter, response(http.StatusOK), time.Millisecond)
+	}
+	if got := c.Rate(); got != 4 {
+		t.Fatalf("rate should cap at max, got %v", got)
+	}
+	a.observe(c.Limiter, response(http.StatusTooManyRequests), time.Millisecond)
+	if got := c.Rate(); got != 2 {
+		t.Fatalf("rate after 429 = %v, want 2", got)
+	}
+	a.observe(c.Limiter, response(http.StatusServiceUnavailable), time.Millisecond)
+	a.observe(c.Limiter, response(http.StatusServiceUnavailable), time.Millisecond)
+	if got := c.Rate(); got != 1 {
+		t.Fatalf("rate should floor at min, got %v", got)
+	}
+}
+
+func TestAdaptiveRateLatency(t *testing.T) {
+	c := New()
+	c.SetAdaptiveRate(AdaptiveConfig{Min: 0.5, Max: 10, Increase: 1})
+	a := c.adaptive
+	for range latencyWarmup {
+		a.observe(c.Limiter, response(http.StatusOK), 10*time.Millisecond)
+	}
+	before := c.Rate()
+	a.observe(c.Limiter, response(http.StatusOK), 100*time.Millisecond)
+	if got := c.Rate(); got >= before {
+		t.Fatalf("slow response should cut rate: before %v after %v", before, got)
+	}
+}
+
+func TestAdaptiveRateIgnoresCacheAndClamps(t *testing.T) {
+	c := New()
+	c.Limiter = rate.NewLimiter(100, 1)
+	c.SetAdaptiveRate(AdaptiveConfig{Min: 1, Max: 5})
+	if got := c.Rate(); got != 5 {
+		t.Fatalf("initial rate should clamp to max, got %v", got)
+	}
+	cached := response(http.StatusTooManyRequests)
+	cached.Header.Set("X-From-Cache", "1")
+	c.adaptive.observe(c.Limiter, cached, time.Millisecond)
+	if got := c.Rate(); got != 5 {
+		t.Fatalf("cached response changed rate to %v", got)
+	}
+}
+
+func TestAdaptiveRateDefaultsMin(t *testing.T) {
+	for _, cfg := range []AdaptiveConfig{{Max: 5}, {Min: -1, Max: 5}, {}} {
+		c := New()
+		c.SetAdaptiveRate(cfg)
+		if c.adaptive.cfg.Min != DefaultMinRate || c.adaptive.cfg.Increase <= 0 {
+			t.Fatalf("%+v: config = %+v", cfg, c.adaptive.cfg)
+		}
+		for range 20 {
+			c.adaptive.observe(c.Limiter, response(http.StatusTooManyRequests), time.Millisecond)
+		}
+		if got := c.Rate(); got != DefaultMinRate {
+			t.Fatalf("%+v: rate after throttling = %v, want %v", cfg, got, DefaultMinRate)
+		}
+	}
+}
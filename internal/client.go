@@ -16,6 +16,13 @@ type Client struct {
 	client  *retryablehttp.Client
 	std     *http.Client
 	Limiter *rate.Limiter
+	// Observer, when set, receives instrumentation events.
+	Observer Observer
+	// Tracer, when set, starts a span for every attempt.
+	Tracer   Tracer
+	log      LogConfig
+	flights  group
+	adaptive *adaptiveRate
 }
 
 // New constructs a configured Client.
@@ -24,7 +31,6 @@ func New() *Client {
 	rc := retryablehttp.NewClient()
 	rc.RetryMax = 4
 	rc.Backoff = retryablehttp.LinearJitterBackoff
-	rc.HTTPClient.Transport = cache
 	rc.Logger = nil
 	rc.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 		if err != nil {
@@ -38,11 +44,27 @@ func New() *Client {
 		}
 		return false, nil
 	}
-	return &Client{
+	// Once retries run out, return the last response rather than a generic
+	// "giving up" error, so callers can tell rate limiting and server
+	// errors from network failures.
+	rc.ErrorHandler = func(resp *http.Response, err error, _ int) (*http.Response, error) {
+		if err != nil {
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			return nil, err
+		}
+		return resp, nil
+	}
+	c := &Client{
 		client:  rc,
 		std:     rc.StandardClient(),
 		Limiter: rate.NewLimiter(rate.Every(time.Second), 1),
 	}
+	rc.HTTPClient.Transport = &tracingTransport{c: c, next: cache}
+	rc.RequestLogHook = c.onAttempt
+	rc.ResponseLogHook = c.onResponse
+	return c
 }
 
 // SetRetryWait overrides the retry backoff bounds ensuring min <= max.
@@ -57,10 +79,41 @@ func (c *Client) SetRetryMax(max int) { c.client.RetryMax = max }
 // SetCheckRetry overrides the retry check function.
 func (c *Client) SetCheckRetry(fn retryablehttp.CheckRetry) { c.client.CheckRetry = fn }
 
+// attemptKey carries the *attemptState of a request through its retries.
+type attemptKey struct{}
+
+// attemptState tracks the current attempt of a request.
+type attemptState struct {
+	n     int
+	start time.Time
+}
+
 // Do executes the HTTP request respecting rate limiting and retries.
 func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	start := time.Now()
 	if err := c.Limiter.Wait(ctx); err != nil {
 		return nil, err
 	}
-	return c.std.Do(req.WithContext(ctx))
+	wait := time.Since(start)
+	st := &attemptState{n: 1, start: time.Now()}
+	ctx = context.WithValue(ctx, attemptKey{}, st)
+	req = req.WithContext(ctx)
+	resp, err := c.std.Do(req)
+	c.logRequest(req, resp, err, st.n, start, wait)
+	if c.Observer != nil {
+		c.observe(req, resp, err, start, wait)
+	}
+	return resp, err
+}
+
+func (c *Client) observe(req *http.Request, resp *http.Response, err error, start time.Time, wait time.Duration) {
+	c.Observer.OnRateLimitWait(wait)
+	status := 0
+	if err == nil {
+		status = resp.StatusCode
+		if cacheStatus(resp) == "hit" {
+			c.Observer.OnCacheHit(req.URL.String())
+		}
+	}
+	c.Observer.OnRequest(req.Method, req.URL.String(), status, time.Since(start), err)
 }
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"sync"
+)
+
+// group coalesces concurrent calls that share a key so that only one runs.
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+type call struct {
+	done    chan struct{}
+	val     any
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do runs fn once for all concurrent callers with the same key and hands
+// every caller its result. fn runs with a context that keeps the values of
+// the first caller's context but not its cancellation or deadline; it is
+// canceled only once every caller waiting on it has given up. A caller whose
+// own context ends stops waiting and gets the context's error.
+func (g *group) do(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	c, ok := g.calls[key]
+	if ok {
+		c.waiters++
+	} else {
+		fctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &call{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.calls[key] = c
+		go g.run(fctx, key, c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			c.cancel()
+			g.forget(key, c)
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (g *group) run(ctx context.Context, key string, c *call, fn func(context.Context) (any, error)) {
+	c.val, c.err = fn(ctx)
+	c.cancel()
+	g.mu.Lock()
+	g.forget(key, c)
+	g.mu.Unlock()
+	close(c.done)
+}
+
+// forget removes c from the in-flight set. The caller must hold g.mu.
+func (g *group) forget(key string, c *call) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
+
+// Coalesce runs fn once for concurrent callers using the same key, such as
+// identical requests from many goroutines, and returns the shared result.
+// Callers must treat the result as read-only.
+func (c *Client) Coalesce(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+	return c.flights.do(ctx, key, fn)
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupSharesResult(t *testing.T) {
+	var g group
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(context.Context) (any, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]any, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = g.do(context.Background(), "k", fn)
+		}()
+	}
+	waitForWaiters(t, &g, "k", 5)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Fatalf("fn ran %d times", calls.Load())
+	}
+	for _, r := range results {
+		if r != 42 {
+			t.Fatalf("unexpected result %v", r)
+		}
+	}
+}
+
+func TestGroupFirstCallerCancels(t *testing.T) {
+	var g group
+	release := make(chan struct{})
+	var fnErr atomic.Value
+	fn := func(ctx context.Context) (any, error) {
+		select {
+		case <-release:
+			return "ok", nil
+		case <-ctx.Done():
+			fnErr.Store(ctx.Err())
+			return nil, ctx.Err()
+		}
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := g.do(first, "k", fn)
+		firstErr <- err
+	}()
+	waitForWaiters(t, &g, "k", 1)
+
+	second := make(chan any, 1)
+	go func() {
+		v, _ := g.do(context.Background(), "k", fn)
+		second <- v
+	}()
+	waitForWaiters(t, &g, "k", 2)
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("first caller: %v", err)
+	}
+	close(release)
+	if v := <-second; v != "ok" {
+		t.Fatalf("second caller got %v", v)
+	}
+	if fnErr.Load() != nil {
+		t.Fatalf("shared call was canceled while a caller still waited")
+	}
+}
+
+func TestGroupAllCallersCancel(t *testing.T) {
+	var g group
+	canceled := make(chan struct{})
+	fn := func(ctx context.Context) (any, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := g.do(ctx, "k", fn); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatalf("shared call not canceled after last caller left")
+	}
+}
+
+func waitForWaiters(t *testing.T, g *group, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		c := g.calls[key]
+		got := 0
+		if c != nil {
+			got = c.waiters
+		}
+		g.mu.Unlock()
+		if got == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d waiters", n)
+}
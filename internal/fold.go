@@ -0,0 +1,18 @@
+package internal
+
+// FoldRune returns the base letters of a common lower-case accented letter
+// or ligature, such as "e" for 'é' and "ae" for 'æ', and reports whether r
+// is one. It is shared by the name matching of the root package and the
+// citation keys of package biblio.
+func FoldRune(r rune) (string, bool) {
+	f, ok := accentFold[r]
+	return f, ok
+}
+
+var accentFold = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ì': "i", 'í': "i",
+	'î': "i", 'ï': "i", 'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o",
+	'ö': "o", 'ø': "o", 'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ý': "y",
+	'ÿ': "y", 'ß': "ss", 'œ': "oe", 'š': "s", 'ž': "z", 'č': "c", 'ł': "l",
+}
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// LogConfig controls request logging.
+type LogConfig struct {
+	Logger *slog.Logger
+	// RequestLevel is the level of the per-request summary.
+	RequestLevel slog.Level
+	// AttemptLevel is the level of per-attempt and retry messages.
+	AttemptLevel slog.Level
+	// RedactQuery replaces query parameter values in logged URLs.
+	RedactQuery bool
+}
+
+// SetLogger enables logging of requests, attempts and retries.
+func (c *Client) SetLogger(cfg LogConfig) {
+	c.log = cfg
+	c.client.Logger = &leveledLogger{cfg: cfg}
+}
+
+// onAttempt runs before every attempt, including the first.
+func (c *Client) onAttempt(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	if st, ok := req.Context().Value(attemptKey{}).(*attemptState); ok {
+		st.n = attempt + 1
+		st.start = time.Now()
+	}
+	if attempt > 0 && c.Observer != nil {
+		c.Observer.OnRetry(req.Method, req.URL.String(), attempt+1)
+	}
+	if c.log.Logger != nil {
+		(&leveledLogger{cfg: c.log}).log(c.log.AttemptLevel, "gutendex attempt",
+			"method", req.Method, "url", req.URL.String(), "attempt", attempt+1)
+	}
+}
+
+// onResponse runs after every attempt that produced a response.
+func (c *Client) onResponse(_ retryablehttp.Logger, resp *http.Response) {
+	if c.adaptive != nil {
+		if st, ok := resp.Request.Context().Value(attemptKey{}).(*attemptState); ok {
+			c.adaptive.observe(c.Limiter, resp, time.Since(st.start))
+		}
+	}
+	if c.log.Logger != nil {
+		(&leveledLogger{cfg: c.log}).log(c.log.AttemptLevel, "gutendex attempt response",
+			"method", resp.Request.Method, "url", resp.Request.URL.String(),
+			"status", resp.StatusCode, "cache", cacheStatus(resp))
+	}
+}
+
+// logRequest emits the summary of a completed request.
+func (c *Client) logRequest(req *http.Request, resp *http.Response, err error, attempts int, start time.Time, wait time.Duration) {
+	if c.log.Logger == nil {
+		return
+	}
+	args := []any{
+		"method", req.Method,
+		"url", c.log.redact(req.URL.String()),
+		"duration", time.Since(start),
+		"attempts", attempts,
+		"limiter_wait", wait,
+	}
+	if err != nil {
+		c.log.Logger.Log(req.Context(), max(c.log.RequestLevel, slog.LevelWarn), "gutendex request failed",
+			append(args, "error", c.log.redactValue(err))...)
+		return
+	}
+	args = append(args, "status", resp.StatusCode, "cache", cacheStatus(resp))
+	c.log.Logger.Log(req.Context(), c.log.RequestLevel, "gutendex request", args...)
+}
+
+func cacheStatus(resp *http.Response) string {
+	if resp.Header.Get("X-From-Cache") == "1" {
+		return "hit"
+	}
+	return "miss"
+}
+
+// redact replaces query values in s, which may be a URL or a message
+// containing URLs, when redaction is enabled.
+func (cfg LogConfig) redact(s string) string {
+	if !cfg.RedactQuery || !strings.Contains(s, "?") {
+		return s
+	}
+	fields := strings.Fields(s)
+	for i, f := range fields {
+		u, err := url.Parse(f)
+		if err != nil || u.RawQuery == "" {
+			continue
+		}
+		q := u.Query()
+		for k := range q {
+			q[k] = []string{"REDACTED"}
+		}
+		u.RawQuery = q.Encode()
+		fields[i] = u.String()
+	}
+	return strings.Join(fields, " ")
+}
+
+// redactValue redacts URLs in strings and in errors produced by net/url.
+func (cfg LogConfig) redactValue(v any) any {
+	if !cfg.RedactQuery {
+		return v
+	}
+	switch v := v.(type) {
+	case string:
+		return cfg.redact(v)
+	case error:
+		var ue *url.Error
+		if errors.As(v, &ue) {
+			return (&url.Error{Op: ue.Op, URL: cfg.redact(ue.URL), Err: ue.Err}).Error()
+		}
+	}
+	return v
+}
+
+// leveledLogger adapts slog to retryablehttp.LeveledLogger. Debug messages
+// are emitted at the configured attempt level and URLs are redacted.
+type leveledLogger struct {
+	cfg LogConfig
+}
+
+func (l *leveledLogger) log(level slog.Level, msg string, kv ...any) {
+	for i := 1; i < len(kv); i += 2 {
+		kv[i] = l.cfg.redactValue(kv[i])
+	}
+	l.cfg.Logger.Log(context.Background(), level, msg, kv...)
+}
+
+func (l *leveledLogger) Error(msg string, kv ...interface{}) { l.log(slog.LevelError, msg, kv...) }
+func (l *leveledLogger) Warn(msg string, kv ...interface{})  { l.log(slog.LevelWarn, msg, kv...) }
+func (l *leveledLogger) Info(msg string, kv ...interface{})  { l.log(slog.LevelInfo, msg, kv...) }
+func (l *leveledLogger) Debug(msg string, kv ...interface{}) { l.log(l.cfg.AttemptLevel, msg, kv...) }
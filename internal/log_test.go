@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+func TestSetLoggerLogsAttemptsAndSummary(t *testing.T) {
+	var buf bytes.Buffer
+	c := New()
+	c.Limiter = rate.NewLimiter(rate.Inf, 1)
+	c.SetRetryWait(0, 0)
+	c.SetLogger(LogConfig{
+		Logger:       slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		RequestLevel: slog.LevelInfo,
+		AttemptLevel: slog.LevelDebug,
+		RedactQuery:  true,
+	})
+	attempts := 0
+	c.client.HTTPClient.Transport = roundTripper(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("temporary")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+			Header:     http.Header{"X-From-Cache": {"1"}},
+			Request:    req,
+		}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/books?search=secret", nil)
+	resp, err := c.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	out := buf.String()
+	for _, want := range []string{
+		`level=DEBUG msg="gutendex attempt" method=GET url="http://example.com/books?search=REDACTED" attempt=2`,
+		`level=INFO msg="gutendex request" method=GET url="http://example.com/books?search=REDACTED"`,
+		"attempts=2",
+		"status=200 cache=hit",
+		"limiter_wait=",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("query value leaked into logs:\n%s", out)
+	}
+}
+
+func TestRedactLeavesPlainURLs(t *testing.T) {
+	cfg := LogConfig{RedactQuery: true}
+	if got := cfg.redact("GET http://x/books/1"); got != "GET http://x/books/1" {
+		t.Fatalf("redact = %q", got)
+	}
+	if got := cfg.redact("GET http://x/books?a=1&b=2"); got != "GET http://x/books?a=REDACTED&b=REDACTED" {
+		t.Fatalf("redact = %q", got)
+	}
+}
@@ -0,0 +1,38 @@
+package internal
+
+import "time"
+
+// Observer receives instrumentation events from the client. Implementations
+// must be safe for concurrent use.
+type Observer interface {
+	// OnRequest is called when a request completes. The duration covers the
+	// rate-limiter wait and all retries; the status is zero when err is
+	// non-nil.
+	OnRequest(method, url string, status int, d time.Duration, err error)
+	// OnRetry is called before each retry attempt; attempt starts at 2.
+	OnRetry(method, url string, attempt int)
+	// OnRateLimitWait is called with the time a request spent waiting on
+	// the rate limiter.
+	OnRateLimitWait(d time.Duration)
+	// OnCacheHit is called when a response was served from the HTTP cache.
+	OnCacheHit(url string)
+	// OnDecodeError is called when a response body could not be decoded.
+	OnDecodeError(op string, err error)
+}
+
+// NopObserver implements Observer with no-op methods. Embed it to observe
+// only some events.
+type NopObserver struct{}
+
+func (NopObserver) OnRequest(string, string, int, time.Duration, error) {}
+func (NopObserver) OnRetry(string, string, int)                         {}
+func (NopObserver) OnRateLimitWait(time.Duration)                       {}
+func (NopObserver) OnCacheHit(string)                                   {}
+func (NopObserver) OnDecodeError(string, error)                         {}
+
+// ReportDecodeError notifies the observer, if any, of a decode failure.
+func (c *Client) ReportDecodeError(op string, err error) {
+	if c.Observer != nil {
+		c.Observer.OnDecodeError(op, err)
+	}
+}
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type recordingObserver struct {
+	NopObserver
+	retries   []int
+	cacheHits int
+	statuses  []int
+}
+
+func (o *recordingObserver) OnRetry(_, _ string, attempt int) { o.retries = append(o.retries, attempt) }
+func (o *recordingObserver) OnCacheHit(string)                { o.cacheHits++ }
+func (o *recordingObserver) OnRequest(_, _ string, status int, _ time.Duration, _ error) {
+	o.statuses = append(o.statuses, status)
+}
+
+func TestDoNotifiesObserver(t *testing.T) {
+	c := New()
+	c.Limiter = rate.NewLimiter(rate.Inf, 1)
+	c.SetRetryWait(0, 0)
+	obs := &recordingObserver{}
+	c.Observer = obs
+	attempts := 0
+	c.client.HTTPClient.Transport = roundTripper(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		status := http.StatusOK
+		if attempts < 3 {
+			status = http.StatusServiceUnavailable
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+			Header:     http.Header{"X-From-Cache": {"1"}},
+			Request:    req,
+		}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	resp, err := c.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(obs.retries) != 2 || obs.retries[0] != 2 || obs.retries[1] != 3 {
+		t.Fatalf("retries = %v", obs.retries)
+	}
+	if obs.cacheHits != 1 || len(obs.statuses) != 1 || obs.statuses[0] != http.StatusOK {
+		t.Fatalf("cacheHits=%d statuses=%v", obs.cacheHits, obs.statuses)
+	}
+}
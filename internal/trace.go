@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+)
+
+// Attribute is a key/value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Tracer starts spans. It is satisfied by thin adapters over tracing
+// libraries such as OpenTelemetry, which this module does not depend on.
+type Tracer interface {
+	// Start begins a span named name as a child of any span in ctx and
+	// returns a context carrying the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is an in-progress trace span.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+	// TraceParent returns the W3C traceparent header value identifying the
+	// span, or "" to skip propagation.
+	TraceParent() string
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...Attribute) {}
+func (nopSpan) RecordError(error)          {}
+func (nopSpan) End()                       {}
+func (nopSpan) TraceParent() string        { return "" }
+
+// StartSpan starts a span with the configured tracer, or returns a no-op
+// span when tracing is disabled.
+func (c *Client) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	if c.Tracer == nil {
+		return ctx, nopSpan{}
+	}
+	return c.Tracer.Start(ctx, name, attrs...)
+}
+
+// tracingTransport starts a child span for every attempt and propagates it
+// through the traceparent header.
+type tracingTransport struct {
+	c    *Client
+	next http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.c.Tracer == nil {
+		return t.next.RoundTrip(req)
+	}
+	attempt := 1
+	if st, ok := req.Context().Value(attemptKey{}).(*attemptState); ok {
+		attempt = st.n
+	}
+	ctx, span := t.c.Tracer.Start(req.Context(), "HTTP "+req.Method,
+		Attribute{"http.method", req.Method},
+		Attribute{"http.url", req.URL.String()},
+		Attribute{"http.attempt", attempt},
+	)
+	defer span.End()
+	req = req.Clone(ctx)
+	if tp := span.TraceParent(); tp != "" {
+		req.Header.Set("traceparent", tp)
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(
+		Attribute{"http.status_code", resp.StatusCode},
+		Attribute{"gutendex.cache", cacheStatus(resp)},
+	)
+	return resp, nil
+}
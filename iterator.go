@@ -6,6 +6,8 @@ import (
 	"fmt"
 	internal "github.com/alex-rs/go-gutendex/internal"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // Page represents a paginated response from Gutendex.
@@ -23,11 +25,36 @@ type Iter[T any] struct {
 	buf     []T
 	idx     int
 	err     error
+	ctx     context.Context
+	page    int
+	// count is the total number of items reported by the source, or -1
+	// while unknown.
+	count int
+
+	// pull, when set, replaces HTTP paging as the source of batches.
+	pull func(ctx context.Context) ([]T, bool, error)
+	more bool
 }
 
 // NewIter constructs a new iterator starting at firstURL.
 func NewIter[T any](client *internal.Client, firstURL string) *Iter[T] {
-	return &Iter[T]{client: client, nextURL: firstURL, idx: -1}
+	return &Iter[T]{client: client, nextURL: firstURL, idx: -1, ctx: context.Background(), count: -1}
+}
+
+// newPullIter constructs an iterator fed by pull. Each call returns the next
+// batch of items and whether further batches may follow.
+func newPullIter[T any](pull func(ctx context.Context) ([]T, bool, error)) *Iter[T] {
+	return &Iter[T]{pull: pull, more: true, idx: -1, ctx: context.Background(), count: -1}
+}
+
+// newErrIter constructs an iterator that yields nothing and reports err.
+func newErrIter[T any](err error) *Iter[T] {
+	return &Iter[T]{err: err, idx: -1, ctx: context.Background(), count: -1}
+}
+
+// newSliceIter constructs an iterator over a fixed slice of items.
+func newSliceIter[T any](items []T) *Iter[T] {
+	return &Iter[T]{buf: items, idx: -1, ctx: context.Background(), count: len(items)}
 }
 
 // Next advances the iterator to the next value.
@@ -36,17 +63,14 @@ func (it *Iter[T]) Next() bool {
 		return false
 	}
 	it.idx++
-	if it.idx >= len(it.buf) {
-		if it.nextURL == "" {
+	for it.idx >= len(it.buf) {
+		if !it.hasMore() {
 			return false
 		}
-		if err := it.fetch(context.Background()); err != nil {
+		if err := it.load(it.ctx); err != nil {
 			it.err = err
 			return false
 		}
-		if len(it.buf) == 0 {
-			return false
-		}
 		it.idx = 0
 	}
 	return true
@@ -63,16 +87,92 @@ func (it *Iter[T]) Value() T {
 // Err returns the last error encountered by the iterator.
 func (it *Iter[T]) Err() error { return it.err }
 
+// Context sets the context of the requests made by later calls to Next
+// and returns it, for use as in
+//
+//	it := client.ListBooks(q).Context(ctx)
+//
+// Iterators start with context.Background. Collect, First and Count set
+// the context they are given themselves.
+func (it *Iter[T]) Context(ctx context.Context) *Iter[T] {
+	it.ctx = ctx
+	return it
+}
+
+func (it *Iter[T]) hasMore() bool {
+	if it.pull != nil {
+		return it.more
+	}
+	return it.nextURL != ""
+}
+
+func (it *Iter[T]) load(ctx context.Context) error {
+	if it.pull == nil {
+		return it.fetch(ctx)
+	}
+	items, more, err := it.pull(ctx)
+	if err != nil {
+		return err
+	}
+	it.buf = append(it.buf[:0], items...)
+	it.more = more
+	return nil
+}
+
+// cloner is implemented by item types holding slices or maps, such as
+// Book, so that items of a shared page can be copied for each caller.
+type cloner[T any] interface{ clone() T }
+
+// fetch loads the page at nextURL. Concurrent fetches of the same page
+// through one client share a single request; each iterator gets its own
+// copy of the items.
 func (it *Iter[T]) fetch(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, it.nextURL, nil)
+	it.page++
+	pageURL, page := it.nextURL, it.page
+	var zero T
+	v, err := it.client.Coalesce(ctx, fmt.Sprintf("page %T %s", zero, pageURL), func(ctx context.Context) (any, error) {
+		return fetchPage[T](ctx, it.client, pageURL, page)
+	})
 	if err != nil {
-		return &Error{Op: "iter.fetch", Kind: ErrNetwork, Err: err}
+		return coalesceError("iter.fetch", err)
+	}
+	p := v.(*Page[T])
+	it.buf = append(it.buf[:0], p.Results...)
+	for i, item := range it.buf {
+		if c, ok := any(item).(cloner[T]); ok {
+			it.buf[i] = c.clone()
+		}
+	}
+	it.count = p.Count
+	if p.Next != nil {
+		it.nextURL = *p.Next
+	} else {
+		it.nextURL = ""
 	}
-	resp, err := it.client.Do(ctx, req)
+	return nil
+}
+
+func fetchPage[T any](ctx context.Context, client *internal.Client, pageURL string, page int) (_ *Page[T], err error) {
+	ctx, span := client.StartSpan(ctx, "gutendex.ListBooks.page", pageAttributes(pageURL, page)...)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
 	if err != nil {
-		return &Error{Op: "iter.fetch", Kind: ErrNetwork, Err: err}
+		return nil, &Error{Op: "iter.fetch", Kind: ErrNetwork, Err: err}
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, &Error{Op: "iter.fetch", Kind: ErrNetwork, Err: err}
 	}
 	defer func() { _ = resp.Body.Close() }()
+	span.SetAttributes(
+		internal.Attribute{Key: "http.status_code", Value: resp.StatusCode},
+		internal.Attribute{Key: "gutendex.cache", Value: cacheStatus(resp)},
+	)
 	if resp.StatusCode != http.StatusOK {
 		kind := ErrServer
 		switch resp.StatusCode {
@@ -81,17 +181,26 @@ func (it *Iter[T]) fetch(ctx context.Context) error {
 		case http.StatusTooManyRequests:
 			kind = ErrRateLimited
 		}
-		return &Error{Op: "iter.fetch", Kind: kind, Err: fmt.Errorf("status %d", resp.StatusCode)}
+		return nil, &Error{Op: "iter.fetch", Kind: kind, Err: fmt.Errorf("status %d", resp.StatusCode)}
 	}
-	var page Page[T]
-	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
-		return &Error{Op: "iter.fetch", Kind: ErrServer, Err: err}
+	var p Page[T]
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		client.ReportDecodeError("iter.fetch", err)
+		return nil, &Error{Op: "iter.fetch", Kind: ErrServer, Err: err}
 	}
-	it.buf = append(it.buf[:0], page.Results...)
-	if page.Next != nil {
-		it.nextURL = *page.Next
-	} else {
-		it.nextURL = ""
+	return &p, nil
+}
+
+// pageAttributes describes a page fetch: its position in the iteration and
+// the query parameters of its URL.
+func pageAttributes(rawURL string, page int) []internal.Attribute {
+	attrs := []internal.Attribute{{Key: "gutendex.page", Value: page}}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return attrs
 	}
-	return nil
+	for k, v := range u.Query() {
+		attrs = append(attrs, internal.Attribute{Key: "gutendex.query." + k, Value: strings.Join(v, ",")})
+	}
+	return attrs
 }
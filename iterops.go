@@ -0,0 +1,164 @@
+package gutendex
+
+import (
+	"context"
+	"iter"
+)
+
+// The combinators below wrap an Iter in another Iter. They pull from the
+// wrapped iterator only as far as their caller iterates, so an early stop,
+// such as the end of Take, fetches no further pages. Errors of the wrapped
+// iterator are reported by Err of the outermost one. Each combinator takes
+// ownership of the wrapped iterator, which must not be used afterwards.
+
+// Take returns an iterator over the first n values of it.
+func (it *Iter[T]) Take(n int) *Iter[T] {
+	taken := 0
+	return newPullIter(func(ctx context.Context) ([]T, bool, error) {
+		if taken >= n {
+			return nil, false, nil
+		}
+		it.ctx = ctx
+		if !it.Next() {
+			return nil, false, it.Err()
+		}
+		taken++
+		return []T{it.Value()}, taken < n, nil
+	})
+}
+
+// Skip returns an iterator over the values of it after the first n.
+func (it *Iter[T]) Skip(n int) *Iter[T] {
+	skipped := 0
+	return newPullIter(func(ctx context.Context) ([]T, bool, error) {
+		it.ctx = ctx
+		for ; skipped < n; skipped++ {
+			if !it.Next() {
+				return nil, false, it.Err()
+			}
+		}
+		if !it.Next() {
+			return nil, false, it.Err()
+		}
+		return []T{it.Value()}, true, nil
+	})
+}
+
+// All returns it as a sequence for range loops. A failure is yielded once,
+// as the final pair, with the zero value:
+//
+//	for b, err := range client.ListBooks(q).All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (it *Iter[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Collect returns every remaining value of it. It stops with ctx's error
+// if ctx ends first.
+func (it *Iter[T]) Collect(ctx context.Context) ([]T, error) {
+	var out []T
+	err := it.each(ctx, func(v T) bool {
+		out = append(out, v)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// First returns the next value of it and reports whether there was one.
+func (it *Iter[T]) First(ctx context.Context) (T, bool, error) {
+	var first T
+	found := false
+	err := it.each(ctx, func(v T) bool {
+		first, found = v, true
+		return false
+	})
+	return first, found, err
+}
+
+// Count consumes it and returns the number of values it produced.
+func (it *Iter[T]) Count(ctx context.Context) (int, error) {
+	n := 0
+	err := it.each(ctx, func(T) bool {
+		n++
+		return true
+	})
+	return n, err
+}
+
+// each calls fn with the values of it until fn returns false, the values
+// run out or ctx ends.
+func (it *Iter[T]) each(ctx context.Context, fn func(T) bool) error {
+	it.ctx = ctx
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !fn(it.Value()) {
+			return nil
+		}
+	}
+	return it.Err()
+}
+
+// Map returns an iterator over the values of it transformed by f.
+func Map[T, U any](it *Iter[T], f func(T) U) *Iter[U] {
+	return newPullIter(func(ctx context.Context) ([]U, bool, error) {
+		it.ctx = ctx
+		if !it.Next() {
+			return nil, false, it.Err()
+		}
+		return []U{f(it.Value())}, true, nil
+	})
+}
+
+// Batch returns an iterator over consecutive chunks of size values of it.
+// The last chunk may be shorter. Each chunk is a new slice. If it fails
+// partway through a chunk, the values read so far are yielded as a short
+// chunk before the error is reported.
+func Batch[T any](it *Iter[T], size int) *Iter[[]T] {
+	size = max(size, 1)
+	return newPullIter(func(ctx context.Context) ([][]T, bool, error) {
+		it.ctx = ctx
+		chunk := make([]T, 0, size)
+		for len(chunk) < size && it.Next() {
+			chunk = append(chunk, it.Value())
+		}
+		err := it.Err()
+		if len(chunk) == 0 {
+			return nil, false, err
+		}
+		// After an error, one more pull reports it.
+		return [][]T{chunk}, len(chunk) == size || err != nil, nil
+	})
+}
+
+// Dedupe returns an iterator over the values of it whose key has not been
+// seen before, such as books repeated across overlapping listings.
+func Dedupe[T any, K comparable](it *Iter[T], key func(T) K) *Iter[T] {
+	seen := make(map[K]bool)
+	return it.Filter(func(v T) bool {
+		k := key(v)
+		if seen[k] {
+			return false
+		}
+		seen[k] = true
+		return true
+	})
+}
@@ -0,0 +1,146 @@
+package gutendex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func ints(n int) *Iter[int] {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i + 1
+	}
+	return newSliceIter(s)
+}
+
+func TestIterCombinators(t *testing.T) {
+	ctx := context.Background()
+	check := func(name string, got []int, err error, want []int) {
+		t.Helper()
+		if err != nil || !slices.Equal(got, want) {
+			t.Errorf("%s = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	got, err := ints(10).Skip(2).Take(3).Collect(ctx)
+	check("Skip.Take", got, err, []int{3, 4, 5})
+	got, err = ints(3).Take(0).Collect(ctx)
+	check("Take(0)", got, err, nil)
+	got, err = ints(3).Skip(5).Collect(ctx)
+	check("Skip past end", got, err, nil)
+	got, err = Map(ints(3), func(i int) int { return i * 10 }).Collect(ctx)
+	check("Map", got, err, []int{10, 20, 30})
+	got, err = Dedupe(newSliceIter([]int{1, 2, 1, 3, 2}), func(i int) int { return i }).Collect(ctx)
+	check("Dedupe", got, err, []int{1, 2, 3})
+
+	batches, err := Batch(ints(5), 2).Collect(ctx)
+	if err != nil || len(batches) != 3 || !slices.Equal(batches[2], []int{5}) {
+		t.Errorf("Batch = %v, %v", batches, err)
+	}
+	if n, err := ints(7).Filter(func(i int) bool { return i%2 == 0 }).Count(ctx); n != 3 || err != nil {
+		t.Errorf("Count = %d, %v", n, err)
+	}
+	if v, ok, err := ints(3).Skip(1).First(ctx); v != 2 || !ok || err != nil {
+		t.Errorf("First = %d, %v, %v", v, ok, err)
+	}
+	if _, ok, err := ints(0).First(ctx); ok || err != nil {
+		t.Errorf("First of empty = %v, %v", ok, err)
+	}
+
+	var seen []int
+	for v, err := range ints(5).All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v > 3 {
+			break
+		}
+		seen = append(seen, v)
+	}
+	check("All", seen, nil, []int{1, 2, 3})
+}
+
+func TestIterCombinatorErrors(t *testing.T) {
+	boom := errors.New("boom")
+	failing := func() *Iter[int] {
+		calls := 0
+		return newPullIter(func(context.Context) ([]int, bool, error) {
+			if calls++; calls > 1 {
+				return nil, false, boom
+			}
+			return []int{1, 2}, true, nil
+		})
+	}
+	if _, err := Map(failing(), func(i int) int { return i }).Collect(context.Background()); !errors.Is(err, boom) {
+		t.Errorf("Collect err = %v", err)
+	}
+	if _, err := Batch(failing(), 5).Collect(context.Background()); !errors.Is(err, boom) {
+		t.Errorf("Batch err = %v", err)
+	}
+	var chunks [][]int
+	var last error
+	for chunk, err := range Batch(failing(), 5).All() {
+		if err != nil {
+			last = err
+			break
+		}
+		chunks = append(chunks, chunk)
+	}
+	if len(chunks) != 1 || !slices.Equal(chunks[0], []int{1, 2}) || !errors.Is(last, boom) {
+		t.Errorf("Batch before the failure = %v, then %v", chunks, last)
+	}
+	if got, err := failing().Take(2).Collect(context.Background()); err != nil || len(got) != 2 {
+		t.Errorf("Take before the failure = %v, %v", got, err)
+	}
+	last = nil
+	for _, err := range failing().All() {
+		last = err
+	}
+	if !errors.Is(last, boom) {
+		t.Errorf("All final err = %v", last)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ints(3).Collect(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Collect with canceled ctx = %v", err)
+	}
+}
+
+func TestTakeStopsFetching(t *testing.T) {
+	requests := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = fmt.Fprintf(w, `{"count":100,"next":"%s/books?page=%d","previous":null,"results":[{"id":%d},{"id":%d}]}`,
+			srv.URL, requests+1, requests*2-1, requests*2)
+	}))
+	defer srv.Close()
+
+	books, err := newTestClient(srv.URL).ListBooks(Query{}).Take(4).Collect(context.Background())
+	if err != nil || len(books) != 4 {
+		t.Fatalf("Collect = %d books, %v", len(books), err)
+	}
+	if requests != 2 {
+		t.Fatalf("fetched %d pages for 4 books", requests)
+	}
+}
+
+func TestIterContext(t *testing.T) {
+	var got []context.Context
+	it := newPullIter(func(ctx context.Context) ([]int, bool, error) {
+		got = append(got, ctx)
+		return []int{1}, true, nil
+	})
+	ctx := context.WithValue(context.Background(), struct{}{}, 1)
+	if it.Context(ctx) != it || !it.Next() {
+		t.Fatal("Context did not return a usable iterator")
+	}
+	if len(got) != 1 || got[0] != ctx {
+		t.Errorf("pull got contexts %v, want %v", got, ctx)
+	}
+}
@@ -0,0 +1,59 @@
+// Package language maps the language codes found in Book.Languages and
+// accepted by the Gutendex languages filter to English and native names.
+//
+// Gutenberg uses ISO 639-1 two-letter codes, falling back to ISO 639-2 or
+// 639-3 codes such as "grc" (Ancient Greek) for languages that have none.
+package language
+
+import (
+	"slices"
+	"strings"
+)
+
+// Language describes a language code.
+type Language struct {
+	// Code is the lowercase language code, e.g. "de".
+	Code string
+	// Name is the English name, e.g. "German".
+	Name string
+	// Native is the name in the language itself, e.g. "Deutsch".
+	Native string
+}
+
+var byCode = func() map[string]Language {
+	m := make(map[string]Language, len(languages))
+	for _, l := range languages {
+		m[l.Code] = l
+	}
+	return m
+}()
+
+// Normalize lowercases code and trims surrounding space.
+func Normalize(code string) string { return strings.ToLower(strings.TrimSpace(code)) }
+
+// Lookup returns the language with the given code, ignoring case.
+func Lookup(code string) (Language, bool) {
+	l, ok := byCode[Normalize(code)]
+	return l, ok
+}
+
+// Valid reports whether code is a known language code.
+func Valid(code string) bool {
+	_, ok := Lookup(code)
+	return ok
+}
+
+// Name returns the English name of code, or code itself if it is unknown.
+func Name(code string) string {
+	if l, ok := Lookup(code); ok {
+		return l.Name
+	}
+	return code
+}
+
+// All returns every known language, ordered by code.
+func All() []Language {
+	out := slices.Clone(languages)
+	slices.SortFunc(out, func(a, b Language) int { return strings.Compare(a.Code, b.Code) })
+	return out
+}
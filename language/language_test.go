@@ -0,0 +1,36 @@
+package language
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	l, ok := Lookup(" DE ")
+	if !ok || l.Name != "German" || l.Native != "Deutsch" {
+		t.Fatalf("Lookup(DE) = %+v, %v", l, ok)
+	}
+	if _, ok := Lookup("xx"); ok {
+		t.Fatal("unexpected language for xx")
+	}
+	if !Valid("grc") || Valid("") {
+		t.Fatal("Valid misreports codes")
+	}
+	if Name("eo") != "Esperanto" || Name("zz") != "zz" {
+		t.Fatalf("Name = %q, %q", Name("eo"), Name("zz"))
+	}
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+	seen := make(map[string]bool)
+	for i, l := range all {
+		if l.Code == "" || l.Name == "" || l.Native == "" || Normalize(l.Code) != l.Code {
+			t.Errorf("incomplete entry %+v", l)
+		}
+		if seen[l.Code] {
+			t.Errorf("duplicate code %q", l.Code)
+		}
+		seen[l.Code] = true
+		if i > 0 && all[i-1].Code > l.Code {
+			t.Fatalf("not sorted at %q", l.Code)
+		}
+	}
+}
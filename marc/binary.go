@@ -0,0 +1,166 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ISO 2709 delimiters.
+const (
+	subfieldDelimiter = 0x1F
+	fieldTerminator   = 0x1E
+	recordTerminator  = 0x1D
+)
+
+// MarshalBinary encodes r in the ISO 2709 exchange format.
+func (r *Record) MarshalBinary() ([]byte, error) {
+	var dir, data bytes.Buffer
+	for _, f := range r.Fields {
+		if len(f.Tag) != 3 {
+			return nil, fmt.Errorf("marc: invalid tag %q", f.Tag)
+		}
+		start := data.Len()
+		if f.IsControl() {
+			data.WriteString(f.Value)
+		} else {
+			data.WriteByte(indicator(f.Ind1))
+			data.WriteByte(indicator(f.Ind2))
+			for _, s := range f.Subfields {
+				data.WriteByte(subfieldDelimiter)
+				data.WriteByte(s.Code)
+				data.WriteString(s.Value)
+			}
+		}
+		data.WriteByte(fieldTerminator)
+		length := data.Len() - start
+		if length > 9999 || start > 99999 {
+			return nil, errors.New("marc: field too long for ISO 2709")
+		}
+		fmt.Fprintf(&dir, "%s%04d%05d", f.Tag, length, start)
+	}
+	dir.WriteByte(fieldTerminator)
+	data.WriteByte(recordTerminator)
+
+	base := 24 + dir.Len()
+	total := base + data.Len()
+	if total > 99999 {
+		return nil, errors.New("marc: record too long for ISO 2709")
+	}
+	leader := []byte(r.Leader)
+	if len(leader) != 24 {
+		leader = []byte(defaultLeader)
+	}
+	copy(leader[0:5], fmt.Sprintf("%05d", total))
+	copy(leader[12:17], fmt.Sprintf("%05d", base))
+
+	out := make([]byte, 0, total)
+	out = append(out, leader...)
+	out = append(out, dir.Bytes()...)
+	return append(out, data.Bytes()...), nil
+}
+
+// UnmarshalBinary decodes a single ISO 2709 record.
+func (r *Record) UnmarshalBinary(b []byte) error {
+	if len(b) < 25 {
+		return errors.New("marc: record too short")
+	}
+	base, ok := digits(b[12:17])
+	if !ok || base < 25 || base > len(b) || b[base-1] != fieldTerminator {
+		return fmt.Errorf("marc: invalid base address %q", b[12:17])
+	}
+	r.Leader = string(b[:24])
+	r.Fields = nil
+	dir := b[24 : base-1]
+	if len(dir)%12 != 0 {
+		return errors.New("marc: malformed directory")
+	}
+	data := b[base:]
+	for i := 0; i < len(dir); i += 12 {
+		tag := string(dir[i : i+3])
+		length, ok1 := digits(dir[i+3 : i+7])
+		start, ok2 := digits(dir[i+7 : i+12])
+		if !ok1 || !ok2 || start+length > len(data) || length < 1 {
+			return fmt.Errorf("marc: malformed directory entry for %s", tag)
+		}
+		raw := data[start : start+length-1]
+		f := Field{Tag: tag}
+		if f.IsControl() {
+			f.Value = string(raw)
+		} else {
+			if len(raw) < 2 {
+				return fmt.Errorf("marc: field %s lacks indicators", tag)
+			}
+			f.Ind1, f.Ind2 = raw[0], raw[1]
+			for _, part := range bytes.Split(raw[2:], []byte{subfieldDelimiter})[1:] {
+				if len(part) == 0 {
+					continue
+				}
+				f.Subfields = append(f.Subfields, Subfield{Code: part[0], Value: string(part[1:])})
+			}
+		}
+		r.Fields = append(r.Fields, f)
+	}
+	return nil
+}
+
+// digits parses a fixed-width numeric field of a leader or directory,
+// which must consist of ASCII digits only.
+func digits(b []byte) (int, bool) {
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+// EncodeBinary writes records as a stream of ISO 2709 records.
+func EncodeBinary(w io.Writer, records ...*Record) error {
+	for _, r := range records {
+		b, err := r.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeBinary reads every ISO 2709 record from rd.
+func DecodeBinary(rd io.Reader) ([]*Record, error) {
+	br := bufio.NewReader(rd)
+	var out []*Record
+	for {
+		raw, err := br.ReadBytes(recordTerminator)
+		if len(bytes.TrimSpace(raw)) > 0 {
+			if err != nil {
+				return nil, errors.New("marc: truncated record")
+			}
+			r := new(Record)
+			if err := r.UnmarshalBinary(raw); err != nil {
+				return nil, err
+			}
+			out = append(out, r)
+		}
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func indicator(b byte) byte {
+	if b == 0 {
+		return ' '
+	}
+	return b
+}
@@ -0,0 +1,268 @@
+package marc
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+	"github.com/alex-rs/go-gutendex/subject"
+)
+
+// Fields written by FromBook:
+//
+//	001/003  ebook number, "PGUSA"
+//	008      fixed data with the primary language
+//	041      language codes
+//	100/700  authors and translators ($a name, $d dates, $e relator)
+//	245      title
+//	264      publisher
+//	336      content type (Book.MediaType)
+//	542      copyright status
+//	650/651  subjects, with subdivisions coded by kind ($v, $x, $y, $z)
+//	655      bookshelves
+//	856      one link per format ($u URL, $q MIME type)
+//
+// The download count has no MARC equivalent and is not preserved.
+
+// FromBook converts b into a MARC 21 bibliographic record.
+func FromBook(b gutendex.Book) *Record {
+	r := &Record{Leader: defaultLeader}
+	add := func(f Field) { r.Fields = append(r.Fields, f) }
+	sub := func(code byte, v string) Subfield { return Subfield{Code: code, Value: v} }
+
+	add(Field{Tag: "001", Value: strconv.Itoa(b.ID)})
+	add(Field{Tag: "003", Value: "PGUSA"})
+	add(Field{Tag: "008", Value: fixedData(b)})
+	if len(b.Languages) > 0 {
+		f := Field{Tag: "041", Ind1: ' ', Ind2: ' '}
+		for _, l := range b.Languages {
+			f.Subfields = append(f.Subfields, sub('a', marcLanguage(l)))
+		}
+		add(f)
+	}
+	if len(b.Authors) > 0 {
+		add(personField("100", b.Authors[0], "author"))
+	}
+	ind1 := byte('0')
+	if len(b.Authors) > 0 {
+		ind1 = '1'
+	}
+	add(Field{Tag: "245", Ind1: ind1, Ind2: nonfiling(b.Title), Subfields: []Subfield{sub('a', b.Title)}})
+	add(Field{Tag: "264", Ind1: ' ', Ind2: '1', Subfields: []Subfield{sub('b', "Project Gutenberg")}})
+	if b.MediaType != "" {
+		add(Field{Tag: "336", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{sub('a', b.MediaType), sub('2', "rdacontent")}})
+	}
+	if b.Copyright != nil {
+		status := publicDomain
+		if *b.Copyright {
+			status = copyrighted
+		}
+		add(Field{Tag: "542", Ind1: '1', Ind2: ' ', Subfields: []Subfield{sub('l', status)}})
+	}
+	for _, s := range b.Subjects {
+		add(subjectField(subject.Parse(s)))
+	}
+	for _, s := range b.Bookshelves {
+		add(Field{Tag: "655", Ind1: ' ', Ind2: '7', Subfields: []Subfield{sub('a', s), sub('2', "local")}})
+	}
+	for _, p := range b.Authors[min(1, len(b.Authors)):] {
+		add(personField("700", p, "author"))
+	}
+	for _, p := range b.Translators {
+		add(personField("700", p, "translator"))
+	}
+	mimes := make([]string, 0, len(b.Formats))
+	for m := range b.Formats {
+		mimes = append(mimes, m)
+	}
+	slices.SortFunc(mimes, cmp.Compare)
+	for _, m := range mimes {
+		add(Field{Tag: "856", Ind1: '4', Ind2: '0', Subfields: []Subfield{sub('u', b.Formats[m]), sub('q', m)}})
+	}
+	return r
+}
+
+// ToBook converts a record produced by FromBook, or a similar record from
+// another catalog, back into a book.
+func ToBook(r *Record) gutendex.Book {
+	var b gutendex.Book
+	for _, f := range r.Fields {
+		switch f.Tag {
+		case "001":
+			b.ID, _ = strconv.Atoi(strings.TrimSpace(f.Value))
+		case "041":
+			for _, l := range f.All('a') {
+				b.Languages = append(b.Languages, isoLanguage(l))
+			}
+		case "100":
+			b.Authors = append(b.Authors, person(f))
+		case "245":
+			b.Title = f.Get('a')
+		case "336":
+			b.MediaType = f.Get('a')
+		case "542":
+			c := f.Get('l') == copyrighted
+			b.Copyright = &c
+		case "650", "651":
+			var parts []string
+			for _, s := range f.Subfields {
+				if s.Code == 'a' || s.Code == 'x' || s.Code == 'y' || s.Code == 'z' || s.Code == 'v' {
+					parts = append(parts, s.Value)
+				}
+			}
+			b.Subjects = append(b.Subjects, strings.Join(parts, " -- "))
+		case "655":
+			b.Bookshelves = append(b.Bookshelves, f.Get('a'))
+		case "700":
+			if f.Get('e') == "translator" {
+				b.Translators = append(b.Translators, person(f))
+			} else {
+				b.Authors = append(b.Authors, person(f))
+			}
+		case "856":
+			if u := f.Get('u'); u != "" {
+				if b.Formats == nil {
+					b.Formats = make(map[string]string)
+				}
+				b.Formats[f.Get('q')] = u
+			}
+		}
+	}
+	return b
+}
+
+const (
+	publicDomain = "Public domain in the USA."
+	copyrighted  = "Copyrighted."
+)
+
+// subdivisionCodes maps the kind of a heading subdivision to its subfield.
+var subdivisionCodes = map[subject.Kind]byte{
+	subject.Topical:       'x',
+	subject.Geographic:    'z',
+	subject.Chronological: 'y',
+	subject.Form:          'v',
+}
+
+// subjectField builds a 650 field, or a 651 field for headings whose main
+// heading is a place.
+func subjectField(h subject.Heading) Field {
+	f := Field{Tag: "650", Ind1: ' ', Ind2: '0'}
+	if len(h.Parts) > 0 && h.Parts[0].Kind == subject.Geographic {
+		f.Tag = "651"
+	}
+	for i, p := range h.Parts {
+		code := subdivisionCodes[p.Kind]
+		if i == 0 {
+			code = 'a'
+		}
+		f.Subfields = append(f.Subfields, Subfield{Code: code, Value: p.Term})
+	}
+	return f
+}
+
+func personField(tag string, p gutendex.Person, relator string) Field {
+	ind1 := byte('0')
+	if strings.Contains(p.Name, ",") {
+		ind1 = '1'
+	}
+	f := Field{Tag: tag, Ind1: ind1, Ind2: ' ', Subfields: []Subfield{{Code: 'a', Value: p.Name}}}
+	if p.BirthYear != nil || p.DeathYear != nil {
+		f.Subfields = append(f.Subfields, Subfield{Code: 'd', Value: formatYear(p.BirthYear) + "-" + formatYear(p.DeathYear)})
+	}
+	f.Subfields = append(f.Subfields, Subfield{Code: 'e', Value: relator})
+	return f
+}
+
+func person(f Field) gutendex.Person {
+	p := gutendex.Person{Name: f.Get('a')}
+	if m := datesRe.FindStringSubmatch(f.Get('d')); m != nil {
+		p.BirthYear = parseYear(m[1])
+		p.DeathYear = parseYear(m[2])
+	}
+	return p
+}
+
+var datesRe = regexp.MustCompile(`^\s*((?:\d+(?: B\.C\.)?)?)-((?:\d+(?: B\.C\.)?)?)`)
+
+func formatYear(y *int) string {
+	switch {
+	case y == nil:
+		return ""
+	case *y < 0:
+		return strconv.Itoa(-*y) + " B.C."
+	default:
+		return strconv.Itoa(*y)
+	}
+}
+
+func parseYear(s string) *int {
+	digits, bc := strings.CutSuffix(s, " B.C.")
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return nil
+	}
+	if bc {
+		n = -n
+	}
+	return &n
+}
+
+// fixedData builds the 40-character 008 field: dates unknown, online
+// resource, primary language at positions 35-37.
+func fixedData(b gutendex.Book) string {
+	f := []byte(strings.Repeat("|", 40))
+	copy(f[0:6], "      ")
+	f[6] = 'n'
+	copy(f[7:15], "uuuuuuuu")
+	copy(f[15:18], "xx ")
+	f[23] = 'o'
+	lang := "und"
+	if len(b.Languages) > 0 {
+		lang = marcLanguage(b.Languages[0])
+	}
+	copy(f[35:38], lang)
+	f[38] = ' '
+	f[39] = 'd'
+	return string(f)
+}
+
+// nonfiling returns the 245 second indicator: the number of leading
+// characters to skip when sorting, for initial articles.
+func nonfiling(title string) byte {
+	for _, article := range []string{"The ", "An ", "A "} {
+		if strings.HasPrefix(title, article) {
+			return byte('0' + len(article))
+		}
+	}
+	return '0'
+}
+
+// marcLanguage maps ISO 639-1 codes to MARC language codes. Unknown codes
+// are passed through.
+func marcLanguage(code string) string {
+	if m, ok := toMARC[code]; ok {
+		return m
+	}
+	return code
+}
+
+func isoLanguage(code string) string {
+	for iso, m := range toMARC {
+		if m == code {
+			return iso
+		}
+	}
+	return code
+}
+
+var toMARC = map[string]string{
+	"ar": "ara", "ca": "cat", "cs": "cze", "cy": "wel", "da": "dan", "de": "ger",
+	"el": "gre", "en": "eng", "eo": "epo", "es": "spa", "fi": "fin", "fr": "fre",
+	"ga": "gle", "he": "heb", "hu": "hun", "it": "ita", "ja": "jpn", "la": "lat",
+	"nl": "dut", "no": "nor", "pl": "pol", "pt": "por", "ru": "rus", "sv": "swe",
+	"tl": "tgl", "zh": "chi",
+}
@@ -0,0 +1,188 @@
+package marc
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+func intPtr(n int) *int    { return &n }
+func boolPtr(b bool) *bool { return &b }
+
+var iliad = gutendex.Book{
+	ID:    6130,
+	Title: "The Iliad",
+	Authors: []gutendex.Person{
+		{Name: "Homer", BirthYear: intPtr(-750), DeathYear: intPtr(-650)},
+	},
+	Translators: []gutendex.Person{
+		{Name: "Pope, Alexander", BirthYear: intPtr(1688), DeathYear: intPtr(1744)},
+	},
+	Subjects:    []string{"Epic poetry, Greek -- Translations into English", "Trojan War -- Poetry"},
+	Bookshelves: []string{"Classical Antiquity"},
+	Languages:   []string{"en", "grc"},
+	Copyright:   boolPtr(false),
+	MediaType:   "Text",
+	Formats: map[string]string{
+		"text/html":            "https://www.gutenberg.org/ebooks/6130.html.images",
+		"application/epub+zip": "https://www.gutenberg.org/ebooks/6130.epub3.images",
+	},
+}
+
+func TestFromBook(t *testing.T) {
+	r := FromBook(iliad)
+	f100 := r.FieldsByTag("100")
+	if len(f100) != 1 || f100[0].Ind1 != '0' || f100[0].Get('d') != "750 B.C.-650 B.C." {
+		t.Fatalf("100 = %+v", f100)
+	}
+	f245 := r.FieldsByTag("245")[0]
+	if f245.Ind1 != '1' || f245.Ind2 != '4' {
+		t.Fatalf("245 indicators = %q %q", f245.Ind1, f245.Ind2)
+	}
+	if got := r.FieldsByTag("041")[0].All('a'); !reflect.DeepEqual(got, []string{"eng", "grc"}) {
+		t.Fatalf("041 $a = %q", got)
+	}
+	if got := r.FieldsByTag("008")[0].Value; len(got) != 40 || got[35:38] != "eng" {
+		t.Fatalf("008 = %q", got)
+	}
+	f650 := r.FieldsByTag("650")[0]
+	if f650.Get('a') != "Epic poetry, Greek" || f650.Get('v') != "Translations into English" {
+		t.Fatalf("650 = %+v", f650)
+	}
+	f700 := r.FieldsByTag("700")
+	if len(f700) != 1 || f700[0].Get('e') != "translator" || f700[0].Ind1 != '1' {
+		t.Fatalf("700 = %+v", f700)
+	}
+	if got := len(r.FieldsByTag("856")); got != 2 {
+		t.Fatalf("856 count = %d", got)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	books := []gutendex.Book{iliad, {ID: 1, Title: "Déjà vu"}}
+	var recs []*Record
+	for _, b := range books {
+		recs = append(recs, FromBook(b))
+	}
+	var buf bytes.Buffer
+	if err := EncodeBinary(&buf, recs...); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if data[len(data)-1] != recordTerminator {
+		t.Fatal("missing record terminator")
+	}
+	got, err := DecodeBinary(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(recs) {
+		t.Fatalf("got %d records", len(got))
+	}
+	for i, r := range got {
+		if !reflect.DeepEqual(r.Fields, recs[i].Fields) {
+			t.Errorf("record %d fields = %+v, want %+v", i, r.Fields, recs[i].Fields)
+		}
+		if b := ToBook(r); !reflect.DeepEqual(b, books[i]) {
+			t.Errorf("book %d = %+v, want %+v", i, b, books[i])
+		}
+	}
+}
+
+// malformedRecord is a 48-byte record whose directory gives a negative
+// start offset.
+var malformedRecord = []byte("00048nam a2200037 i 4500" + "2450010-0001" + "\x1e" + "  \x1faTitle\x1e\x1d")
+
+func TestUnmarshalBinaryMalformed(t *testing.T) {
+	good, err := FromBook(iliad).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	noTerminator := bytes.Clone(good)
+	noTerminator[bytes.IndexByte(good, fieldTerminator)] = ' '
+	signedBase := bytes.Clone(good)
+	copy(signedBase[12:17], "+0037")
+	for name, b := range map[string][]byte{
+		"negative start":   malformedRecord,
+		"unterminated dir": noTerminator,
+		"signed base":      signedBase,
+		"truncated":        good[:len(good)/2],
+	} {
+		if err := new(Record).UnmarshalBinary(b); err == nil {
+			t.Errorf("%s: UnmarshalBinary succeeded", name)
+		}
+	}
+}
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	good, err := FromBook(iliad).MarshalBinary()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(good)
+	f.Add(malformedRecord)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		// Any input may be rejected, but none may panic.
+		_ = new(Record).UnmarshalBinary(b)
+	})
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeXML(&buf, FromBook(iliad)); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`<datafield tag="245" ind1="1" ind2="4">`)) {
+		t.Fatalf("unexpected XML:\n%s", buf.String())
+	}
+	recs, err := DecodeXML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 1 {
+		t.Fatalf("got %d records", len(recs))
+	}
+	if b := ToBook(recs[0]); !reflect.DeepEqual(b, iliad) {
+		t.Fatalf("got %+v, want %+v", b, iliad)
+	}
+}
+
+func TestDecodeSample(t *testing.T) {
+	f, err := os.Open("testdata/pg1342.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	recs, err := DecodeXML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := ToBook(recs[0])
+	want := gutendex.Book{
+		ID:        1342,
+		Title:     "Pride and Prejudice",
+		Authors:   []gutendex.Person{{Name: "Austen, Jane", BirthYear: intPtr(1775), DeathYear: intPtr(1817)}},
+		Subjects:  []string{"Courtship -- Fiction", "England -- Fiction"},
+		Languages: []string{"en"},
+		Formats:   map[string]string{"application/epub+zip": "https://www.gutenberg.org/ebooks/1342.epub.noimages"},
+	}
+	if !reflect.DeepEqual(b, want) {
+		t.Fatalf("got %+v, want %+v", b, want)
+	}
+
+	// Re-encoding the sample as binary and back preserves every field.
+	data, err := recs[0].MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r Record
+	if err := r.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.Fields, recs[0].Fields) {
+		t.Fatalf("fields differ after binary round trip")
+	}
+}
@@ -0,0 +1,68 @@
+// Package marc encodes books as MARC 21 bibliographic records, in both the
+// ISO 2709 binary exchange format and MARCXML, and decodes such records
+// back into books.
+package marc
+
+import "strings"
+
+// Record is a MARC 21 record.
+type Record struct {
+	// Leader is the 24-byte record leader. Its length and base address
+	// positions are computed when encoding.
+	Leader string
+	Fields []Field
+}
+
+// Field is a control field (tags 001-009), which carries Value, or a data
+// field, which carries indicators and subfields.
+type Field struct {
+	Tag       string
+	Value     string
+	Ind1      byte
+	Ind2      byte
+	Subfields []Subfield
+}
+
+// Subfield is a coded element of a data field.
+type Subfield struct {
+	Code  byte
+	Value string
+}
+
+// IsControl reports whether f is a control field.
+func (f Field) IsControl() bool { return strings.HasPrefix(f.Tag, "00") }
+
+// Get returns the value of the first subfield with the given code.
+func (f Field) Get(code byte) string {
+	for _, s := range f.Subfields {
+		if s.Code == code {
+			return s.Value
+		}
+	}
+	return ""
+}
+
+// All returns the values of every subfield with the given code.
+func (f Field) All(code byte) []string {
+	var out []string
+	for _, s := range f.Subfields {
+		if s.Code == code {
+			out = append(out, s.Value)
+		}
+	}
+	return out
+}
+
+// FieldsByTag returns the fields with the given tag.
+func (r *Record) FieldsByTag(tag string) []Field {
+	var out []Field
+	for _, f := range r.Fields {
+		if f.Tag == tag {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// defaultLeader describes a Unicode-encoded, full-level monograph.
+const defaultLeader = "00000nam a2200000 i 4500"
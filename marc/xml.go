@@ -0,0 +1,103 @@
+package marc
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Namespace is the MARCXML namespace.
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+type xmlCollection struct {
+	XMLName xml.Name    `xml:"http://www.loc.gov/MARC21/slim collection"`
+	Records []xmlRecord `xml:"record"`
+}
+
+type xmlRecord struct {
+	Leader        string         `xml:"leader"`
+	ControlFields []xmlControl   `xml:"controlfield"`
+	DataFields    []xmlDataField `xml:"datafield"`
+}
+
+type xmlControl struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// EncodeXML writes records as a MARCXML collection.
+func EncodeXML(w io.Writer, records ...*Record) error {
+	c := xmlCollection{}
+	for _, r := range records {
+		leader := r.Leader
+		if len(leader) != 24 {
+			leader = defaultLeader
+		}
+		xr := xmlRecord{Leader: leader}
+		for _, f := range r.Fields {
+			if f.IsControl() {
+				xr.ControlFields = append(xr.ControlFields, xmlControl{Tag: f.Tag, Value: f.Value})
+				continue
+			}
+			df := xmlDataField{Tag: f.Tag, Ind1: string(indicator(f.Ind1)), Ind2: string(indicator(f.Ind2))}
+			for _, s := range f.Subfields {
+				df.Subfields = append(df.Subfields, xmlSubfield{Code: string(s.Code), Value: s.Value})
+			}
+			xr.DataFields = append(xr.DataFields, df)
+		}
+		c.Records = append(c.Records, xr)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// DecodeXML reads the records of a MARCXML collection. Control fields are
+// placed before data fields, as MARCXML requires.
+func DecodeXML(r io.Reader) ([]*Record, error) {
+	var c xmlCollection
+	if err := xml.NewDecoder(r).Decode(&c); err != nil {
+		return nil, err
+	}
+	out := make([]*Record, 0, len(c.Records))
+	for _, xr := range c.Records {
+		rec := &Record{Leader: xr.Leader}
+		for _, cf := range xr.ControlFields {
+			rec.Fields = append(rec.Fields, Field{Tag: cf.Tag, Value: cf.Value})
+		}
+		for _, df := range xr.DataFields {
+			f := Field{Tag: df.Tag, Ind1: firstByte(df.Ind1), Ind2: firstByte(df.Ind2)}
+			for _, s := range df.Subfields {
+				f.Subfields = append(f.Subfields, Subfield{Code: firstByte(s.Code), Value: s.Value})
+			}
+			rec.Fields = append(rec.Fields, f)
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
+
+func firstByte(s string) byte {
+	if s == "" {
+		return ' '
+	}
+	return s[0]
+}
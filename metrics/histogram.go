@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, used for latency
+// histograms. They match the Prometheus client defaults.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Histogram is an expvar.Var recording observations into cumulative
+// buckets, Prometheus style. Its JSON form is
+// {"buckets":{"0.005":n,...,"+Inf":n},"count":n,"sum":x}.
+type Histogram struct {
+	mu      sync.Mutex
+	bounds  []float64
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// NewHistogram constructs a histogram with the given ascending upper
+// bounds. An implicit +Inf bucket is always present.
+func NewHistogram(bounds []float64) *Histogram {
+	return &Histogram{bounds: bounds, buckets: make([]uint64, len(bounds))}
+}
+
+// Observe records v.
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, b := range h.bounds {
+		if v <= b {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+// Count returns the number of observations.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// String implements expvar.Var.
+func (h *Histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var sb strings.Builder
+	sb.WriteString(`{"buckets":{`)
+	for i, b := range h.bounds {
+		fmt.Fprintf(&sb, "%q:%d,", strconv.FormatFloat(b, 'g', -1, 64), h.buckets[i])
+	}
+	fmt.Fprintf(&sb, `"+Inf":%d},"count":%d,"sum":%s}`, h.count, h.count, formatFloat(h.sum))
+	return sb.String()
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return "null"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
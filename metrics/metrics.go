@@ -0,0 +1,93 @@
+// Package metrics exports client instrumentation as expvar variables, in the
+// shape of Prometheus counters and histograms, without extra dependencies.
+//
+//	m := metrics.New("gutendex")
+//	client := gutendex.NewClient(gutendex.WithObserver(m))
+//
+// The variables are served by expvar's /debug/vars handler.
+package metrics
+
+import (
+	"expvar"
+	"strconv"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+var _ gutendex.Observer = (*Observer)(nil)
+
+// Observer is a gutendex.Observer that records events into expvar
+// variables published under a common name.
+type Observer struct {
+	// Requests counts completed requests by status code, or "error".
+	Requests *expvar.Map
+	// RequestDuration records request latency in seconds.
+	RequestDuration *Histogram
+	// Retries counts retry attempts.
+	Retries *expvar.Int
+	// RateLimitWait records time spent waiting on the limiter in seconds.
+	RateLimitWait *Histogram
+	// CacheHits counts responses served from the HTTP cache.
+	CacheHits *expvar.Int
+	// DecodeErrors counts undecodable responses by operation.
+	DecodeErrors *expvar.Map
+
+	vars *expvar.Map
+}
+
+// New constructs an Observer and publishes its variables as an expvar map
+// named name. Like expvar.Publish, it panics if name is already in use, so
+// call it once per name, typically at program start; use NewObserver for
+// an Observer that is not published.
+func New(name string) *Observer {
+	o := NewObserver()
+	expvar.Publish(name, o.vars)
+	return o
+}
+
+// NewObserver constructs an Observer without publishing its variables.
+// Map returns them for publishing later or under a name chosen elsewhere.
+func NewObserver() *Observer {
+	o := &Observer{
+		Requests:        new(expvar.Map).Init(),
+		RequestDuration: NewHistogram(DefaultBuckets),
+		Retries:         new(expvar.Int),
+		RateLimitWait:   NewHistogram(DefaultBuckets),
+		CacheHits:       new(expvar.Int),
+		DecodeErrors:    new(expvar.Map).Init(),
+		vars:            new(expvar.Map).Init(),
+	}
+	o.vars.Set("requests_total", o.Requests)
+	o.vars.Set("request_duration_seconds", o.RequestDuration)
+	o.vars.Set("retries_total", o.Retries)
+	o.vars.Set("rate_limit_wait_seconds", o.RateLimitWait)
+	o.vars.Set("cache_hits_total", o.CacheHits)
+	o.vars.Set("decode_errors_total", o.DecodeErrors)
+	return o
+}
+
+// Map returns the map of o's variables, as published by New.
+func (o *Observer) Map() *expvar.Map { return o.vars }
+
+// OnRequest implements gutendex.Observer.
+func (o *Observer) OnRequest(_, _ string, status int, d time.Duration, err error) {
+	key := "error"
+	if err == nil {
+		key = strconv.Itoa(status)
+	}
+	o.Requests.Add(key, 1)
+	o.RequestDuration.Observe(d.Seconds())
+}
+
+// OnRetry implements gutendex.Observer.
+func (o *Observer) OnRetry(string, string, int) { o.Retries.Add(1) }
+
+// OnRateLimitWait implements gutendex.Observer.
+func (o *Observer) OnRateLimitWait(d time.Duration) { o.RateLimitWait.Observe(d.Seconds()) }
+
+// OnCacheHit implements gutendex.Observer.
+func (o *Observer) OnCacheHit(string) { o.CacheHits.Add(1) }
+
+// OnDecodeError implements gutendex.Observer.
+func (o *Observer) OnDecodeError(op string, _ error) { o.DecodeErrors.Add(op, 1) }
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+func TestHistogramString(t *testing.T) {
+	h := NewHistogram([]float64{0.1, 1})
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(3)
+	want := `{"buckets":{"0.1":1,"1":2,"+Inf":3},"count":3,"sum":3.55}`
+	if got := h.String(); got != want {
+		t.Fatalf("String() = %s, want %s", got, want)
+	}
+	var v map[string]any
+	if err := json.Unmarshal([]byte(h.String()), &v); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+}
+
+func TestObserverEvents(t *testing.T) {
+	o := NewObserver()
+	o.OnRequest("GET", "u", 200, 20*time.Millisecond, nil)
+	o.OnRequest("GET", "u", 0, time.Second, errors.New("x"))
+	o.OnRetry("GET", "u", 2)
+	o.OnRateLimitWait(time.Millisecond)
+	o.OnCacheHit("u")
+	o.OnDecodeError("getJSON", errors.New("bad"))
+
+	if got := o.Requests.Get("200").String(); got != "1" {
+		t.Fatalf("requests 200 = %s", got)
+	}
+	if got := o.Requests.Get("error").String(); got != "1" {
+		t.Fatalf("requests error = %s", got)
+	}
+	if o.RequestDuration.Count() != 2 || o.Retries.Value() != 1 || o.CacheHits.Value() != 1 {
+		t.Fatalf("unexpected counters")
+	}
+	if got := o.DecodeErrors.Get("getJSON").String(); got != "1" {
+		t.Fatalf("decode errors = %s", got)
+	}
+	if o.Map().Get("retries_total") != o.Retries {
+		t.Fatalf("variables missing from Map")
+	}
+}
+
+// published counts the observers published by tests, so that each gets a
+// name of its own even when the tests run several times.
+var published atomic.Int64
+
+func TestNewPublishes(t *testing.T) {
+	name := fmt.Sprintf("test_observer_%d", published.Add(1))
+	o := New(name)
+	if expvar.Get(name) != o.Map() {
+		t.Fatalf("observer not published as %s", name)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New with a name in use did not panic")
+		}
+	}()
+	New(name)
+}
+
+func TestObserverWithClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":`)
+	}))
+	defer srv.Close()
+
+	o := NewObserver()
+	c := gutendex.NewClient(gutendex.WithBaseURL(srv.URL), gutendex.WithObserver(o))
+	if _, err := c.GetBook(context.Background(), 1); err == nil {
+		t.Fatalf("expected decode error")
+	}
+	if got := o.Requests.Get("200"); got == nil || got.String() != "1" {
+		t.Fatalf("requests = %v", o.Requests)
+	}
+	if got := o.DecodeErrors.Get("getJSON"); got == nil || got.String() != "1" {
+		t.Fatalf("decode errors = %v", o.DecodeErrors)
+	}
+	if o.RateLimitWait.Count() != 1 {
+		t.Fatalf("expected a limiter observation")
+	}
+}
@@ -0,0 +1,152 @@
+package opds
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+type atomFeed struct {
+	XMLName      xml.Name    `xml:"feed"`
+	Xmlns        string      `xml:"xmlns,attr"`
+	XmlnsDC      string      `xml:"xmlns:dc,attr"`
+	XmlnsOPDS    string      `xml:"xmlns:opds,attr"`
+	XmlnsSearch  string      `xml:"xmlns:opensearch,attr"`
+	ID           string      `xml:"id"`
+	Title        string      `xml:"title"`
+	Updated      string      `xml:"updated"`
+	TotalResults int         `xml:"opensearch:totalResults,omitempty"`
+	ItemsPerPage int         `xml:"opensearch:itemsPerPage,omitempty"`
+	Links        []link      `xml:"link"`
+	Entries      []atomEntry `xml:"entry"`
+}
+
+type link struct {
+	Rel   string `xml:"rel,attr,omitempty" json:"rel,omitempty"`
+	Href  string `xml:"href,attr" json:"href"`
+	Type  string `xml:"type,attr,omitempty" json:"type,omitempty"`
+	Title string `xml:"title,attr,omitempty" json:"title,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Authors    []atomPerson   `xml:"author"`
+	Translator []string       `xml:"dc:contributor,omitempty"`
+	Languages  []string       `xml:"dc:language"`
+	Categories []atomCategory `xml:"category"`
+	Content    *atomContent   `xml:"content"`
+	Links      []link         `xml:"link"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+func newAtomFeed(f Feed, kind string) *atomFeed {
+	af := &atomFeed{
+		Xmlns:       "http://www.w3.org/2005/Atom",
+		XmlnsDC:     "http://purl.org/dc/terms/",
+		XmlnsOPDS:   "http://opds-spec.org/2010/catalog",
+		XmlnsSearch: "http://a9.com/-/spec/opensearch/1.1/",
+		ID:          f.ID,
+		Title:       f.Title,
+		Updated:     f.updated().Format(time.RFC3339),
+	}
+	if f.SelfURL != "" {
+		af.Links = append(af.Links, link{Rel: "self", Href: f.SelfURL, Type: kind})
+	}
+	if f.StartURL != "" {
+		af.Links = append(af.Links, link{Rel: "start", Href: f.StartURL, Type: NavigationType})
+	}
+	if f.SearchURL != "" {
+		af.Links = append(af.Links, link{Rel: "search", Href: f.SearchURL, Type: OpenSearchType})
+	}
+	return af
+}
+
+// WriteAcquisitionAtom renders page as an OPDS 1.2 acquisition feed.
+func WriteAcquisitionAtom(w io.Writer, f Feed, page gutendex.Page[gutendex.Book]) error {
+	af := newAtomFeed(f, AcquisitionType)
+	af.TotalResults = page.Count
+	af.ItemsPerPage = len(page.Results)
+	if next := f.pageLink(page.Next); next != "" {
+		af.Links = append(af.Links, link{Rel: "next", Href: next, Type: AcquisitionType})
+	}
+	if prev := f.pageLink(page.Previous); prev != "" {
+		af.Links = append(af.Links, link{Rel: "previous", Href: prev, Type: AcquisitionType})
+	}
+	for _, b := range page.Results {
+		e := atomEntry{
+			ID:        bookID(b),
+			Title:     b.Title,
+			Updated:   af.Updated,
+			Languages: b.Languages,
+			Links:     acquisitions(b),
+		}
+		for _, p := range b.Authors {
+			e.Authors = append(e.Authors, atomPerson{Name: p.Name})
+		}
+		for _, p := range b.Translators {
+			e.Translator = append(e.Translator, p.Name)
+		}
+		for _, s := range b.Bookshelves {
+			e.Categories = append(e.Categories, atomCategory{Term: s, Label: s})
+		}
+		for _, s := range b.Subjects {
+			e.Categories = append(e.Categories, atomCategory{Term: s, Label: s})
+		}
+		if cover, thumb := covers(b); cover != "" {
+			e.Links = append(e.Links,
+				link{Rel: RelImage, Href: cover, Type: gutendex.CoverFormat},
+				link{Rel: RelThumbnail, Href: thumb, Type: gutendex.CoverFormat},
+			)
+		}
+		af.Entries = append(af.Entries, e)
+	}
+	return writeXML(w, af)
+}
+
+// WriteNavigationAtom renders entries as an OPDS 1.2 navigation feed.
+func WriteNavigationAtom(w io.Writer, f Feed, entries []NavEntry) error {
+	af := newAtomFeed(f, NavigationType)
+	for _, n := range entries {
+		e := atomEntry{
+			ID:      n.Href,
+			Title:   n.Title,
+			Updated: af.Updated,
+			Links:   []link{{Rel: RelSubsection, Href: n.Href, Type: AcquisitionType}},
+		}
+		if n.Summary != "" {
+			e.Content = &atomContent{Type: "text", Text: n.Summary}
+		}
+		af.Entries = append(af.Entries, e)
+	}
+	return writeXML(w, af)
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
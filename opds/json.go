@@ -0,0 +1,117 @@
+package opds
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+type jsonFeed struct {
+	Metadata     jsonFeedMetadata  `json:"metadata"`
+	Links        []link            `json:"links"`
+	Publications []jsonPublication `json:"publications,omitempty"`
+	Navigation   []link            `json:"navigation,omitempty"`
+}
+
+type jsonFeedMetadata struct {
+	Title         string `json:"title"`
+	Identifier    string `json:"identifier,omitempty"`
+	Modified      string `json:"modified"`
+	NumberOfItems int    `json:"numberOfItems,omitempty"`
+	ItemsPerPage  int    `json:"itemsPerPage,omitempty"`
+}
+
+type jsonPublication struct {
+	Metadata jsonPubMetadata `json:"metadata"`
+	Links    []link          `json:"links"`
+	Images   []link          `json:"images,omitempty"`
+}
+
+type jsonPubMetadata struct {
+	Type       string       `json:"@type"`
+	Identifier string       `json:"identifier"`
+	Title      string       `json:"title"`
+	Author     []jsonPerson `json:"author,omitempty"`
+	Translator []jsonPerson `json:"translator,omitempty"`
+	Language   []string     `json:"language,omitempty"`
+	Subject    []string     `json:"subject,omitempty"`
+}
+
+type jsonPerson struct {
+	Name string `json:"name"`
+}
+
+func newJSONFeed(f Feed) *jsonFeed {
+	jf := &jsonFeed{
+		Metadata: jsonFeedMetadata{Title: f.Title, Identifier: f.ID, Modified: f.updated().Format(time.RFC3339)},
+		Links:    []link{},
+	}
+	if f.SelfURL != "" {
+		jf.Links = append(jf.Links, link{Rel: "self", Href: f.SelfURL, Type: OPDS2Type})
+	}
+	if f.StartURL != "" {
+		jf.Links = append(jf.Links, link{Rel: "start", Href: f.StartURL, Type: OPDS2Type})
+	}
+	if f.SearchURL != "" {
+		jf.Links = append(jf.Links, link{Rel: "search", Href: f.SearchURL, Type: OpenSearchType})
+	}
+	return jf
+}
+
+// WriteAcquisitionJSON renders page as an OPDS 2.0 feed of publications.
+func WriteAcquisitionJSON(w io.Writer, f Feed, page gutendex.Page[gutendex.Book]) error {
+	jf := newJSONFeed(f)
+	jf.Metadata.NumberOfItems = page.Count
+	jf.Metadata.ItemsPerPage = len(page.Results)
+	if next := f.pageLink(page.Next); next != "" {
+		jf.Links = append(jf.Links, link{Rel: "next", Href: next, Type: OPDS2Type})
+	}
+	if prev := f.pageLink(page.Previous); prev != "" {
+		jf.Links = append(jf.Links, link{Rel: "previous", Href: prev, Type: OPDS2Type})
+	}
+	jf.Publications = []jsonPublication{}
+	for _, b := range page.Results {
+		p := jsonPublication{
+			Metadata: jsonPubMetadata{
+				Type:       "http://schema.org/Book",
+				Identifier: bookID(b),
+				Title:      b.Title,
+				Language:   b.Languages,
+				Subject:    append(append([]string(nil), b.Subjects...), b.Bookshelves...),
+			},
+			Links: acquisitions(b),
+		}
+		for _, a := range b.Authors {
+			p.Metadata.Author = append(p.Metadata.Author, jsonPerson{Name: a.Name})
+		}
+		for _, t := range b.Translators {
+			p.Metadata.Translator = append(p.Metadata.Translator, jsonPerson{Name: t.Name})
+		}
+		if p.Links == nil {
+			p.Links = []link{}
+		}
+		if cover, thumb := covers(b); cover != "" {
+			p.Images = []link{{Href: cover, Type: gutendex.CoverFormat}, {Href: thumb, Type: gutendex.CoverFormat, Rel: RelThumbnail}}
+		}
+		jf.Publications = append(jf.Publications, p)
+	}
+	return writeJSON(w, jf)
+}
+
+// WriteNavigationJSON renders entries as an OPDS 2.0 navigation feed.
+func WriteNavigationJSON(w io.Writer, f Feed, entries []NavEntry) error {
+	jf := newJSONFeed(f)
+	jf.Navigation = []link{}
+	for _, n := range entries {
+		jf.Navigation = append(jf.Navigation, link{Rel: RelSubsection, Href: n.Href, Type: OPDS2Type, Title: n.Title})
+	}
+	return writeJSON(w, jf)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
@@ -0,0 +1,146 @@
+// Package opds renders Gutendex results as OPDS catalogs for e-reader apps:
+// OPDS 1.2 Atom feeds, OPDS 2.0 JSON feeds and an OpenSearch description.
+//
+// Acquisition feeds list the books of one Page, with a download link per
+// entry of Book.Formats and cover links from the gutendex.CoverFormat entry.
+// Navigation feeds link to further feeds, such as one per bookshelf.
+package opds
+
+import (
+	"cmp"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// Media types and link relations used in feeds.
+const (
+	AcquisitionType = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	NavigationType  = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	OPDS2Type       = "application/opds+json"
+	OpenSearchType  = "application/opensearchdescription+xml"
+
+	RelAcquisition = "http://opds-spec.org/acquisition/open-access"
+	RelImage       = "http://opds-spec.org/image"
+	RelThumbnail   = "http://opds-spec.org/image/thumbnail"
+	RelSubsection  = "subsection"
+)
+
+// Feed holds the metadata shared by every feed.
+type Feed struct {
+	// ID uniquely identifies the feed, e.g. a URN or its canonical URL.
+	ID    string
+	Title string
+	// Updated defaults to the current time.
+	Updated time.Time
+	// SelfURL is where this feed is served. Pagination links reuse its path
+	// with the query string of Page.Next and Page.Previous.
+	SelfURL string
+	// StartURL links to the catalog root, if set.
+	StartURL string
+	// SearchURL links to the OpenSearch description, if set.
+	SearchURL string
+	// PageURL maps a Gutendex page URL to the URL of the corresponding feed.
+	// It overrides the default mapping based on SelfURL.
+	PageURL func(apiURL string) string
+}
+
+// NavEntry is an entry of a navigation feed.
+type NavEntry struct {
+	Title   string
+	Href    string
+	Summary string
+	// Count is the number of books behind the entry, if known.
+	Count int
+}
+
+func (f Feed) updated() time.Time {
+	if f.Updated.IsZero() {
+		return time.Now().UTC()
+	}
+	return f.Updated.UTC()
+}
+
+// pageLink maps a Gutendex pagination URL to a feed URL.
+func (f Feed) pageLink(apiURL *string) string {
+	if apiURL == nil {
+		return ""
+	}
+	if f.PageURL != nil {
+		return f.PageURL(*apiURL)
+	}
+	api, err := url.Parse(*apiURL)
+	if err != nil {
+		return ""
+	}
+	self, err := url.Parse(f.SelfURL)
+	if err != nil {
+		return ""
+	}
+	self.RawQuery = api.RawQuery
+	return self.String()
+}
+
+// bookID returns the entry identifier of b.
+func bookID(b gutendex.Book) string { return "urn:gutenberg:" + strconv.Itoa(b.ID) }
+
+// acquisitions returns the downloadable formats of b, sorted by type.
+func acquisitions(b gutendex.Book) []link {
+	var out []link
+	for typ, href := range b.Formats {
+		if typ == gutendex.CoverFormat {
+			continue
+		}
+		out = append(out, link{Rel: RelAcquisition, Href: href, Type: typ})
+	}
+	slices.SortFunc(out, func(a, b link) int { return cmp.Compare(a.Type, b.Type) })
+	return out
+}
+
+// covers returns the cover and thumbnail URLs of b. Gutenberg serves a
+// small variant of each medium cover.
+func covers(b gutendex.Book) (cover, thumb string) {
+	cover = b.Formats[gutendex.CoverFormat]
+	thumb = strings.Replace(cover, ".cover.medium.", ".cover.small.", 1)
+	return cover, thumb
+}
+
+// NavigationByBookshelf returns one entry per bookshelf found in books,
+// most populated first, linking to href(shelf).
+func NavigationByBookshelf(books []gutendex.Book, href func(shelf string) string) []NavEntry {
+	return navigation(books, func(b gutendex.Book) []string { return b.Bookshelves }, href)
+}
+
+// NavigationBySubject returns one entry per subject heading found in books.
+func NavigationBySubject(books []gutendex.Book, href func(subject string) string) []NavEntry {
+	return navigation(books, func(b gutendex.Book) []string { return b.Subjects }, href)
+}
+
+// NavigationByLanguage returns one entry per language code found in books.
+func NavigationByLanguage(books []gutendex.Book, href func(code string) string) []NavEntry {
+	return navigation(books, func(b gutendex.Book) []string { return b.Languages }, href)
+}
+
+func navigation(books []gutendex.Book, values func(gutendex.Book) []string, href func(string) string) []NavEntry {
+	counts := make(map[string]int)
+	for _, b := range books {
+		for _, v := range values(b) {
+			counts[v]++
+		}
+	}
+	out := make([]NavEntry, 0, len(counts))
+	for v, n := range counts {
+		out = append(out, NavEntry{Title: v, Href: href(v), Count: n, Summary: strconv.Itoa(n) + " books"})
+	}
+	slices.SortFunc(out, func(a, b NavEntry) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Title, b.Title)
+	})
+	return out
+}
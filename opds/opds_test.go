@@ -0,0 +1,163 @@
+package opds
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+func strPtr(s string) *string { return &s }
+
+var (
+	updated = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	feed    = Feed{
+		ID:        "urn:example:books",
+		Title:     "Books",
+		Updated:   updated,
+		SelfURL:   "https://example.org/opds/books?topic=poetry",
+		StartURL:  "https://example.org/opds",
+		SearchURL: "https://example.org/opds/search.xml",
+	}
+	page = gutendex.Page[gutendex.Book]{
+		Count:    40,
+		Next:     strPtr("https://gutendex.com/books/?page=3&topic=poetry"),
+		Previous: strPtr("https://gutendex.com/books/?topic=poetry"),
+		Results: []gutendex.Book{{
+			ID:          1342,
+			Title:       "Pride & Prejudice",
+			Authors:     []gutendex.Person{{Name: "Austen, Jane"}},
+			Languages:   []string{"en"},
+			Subjects:    []string{"Courtship -- Fiction"},
+			Bookshelves: []string{"Best Books Ever Listings"},
+			Formats: map[string]string{
+				"application/epub+zip": "https://www.gutenberg.org/ebooks/1342.epub3.images",
+				"text/html":            "https://www.gutenberg.org/ebooks/1342.html.images",
+				"image/jpeg":           "https://www.gutenberg.org/cache/epub/1342/pg1342.cover.medium.jpg",
+			},
+		}},
+	}
+)
+
+func TestWriteAcquisitionAtom(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteAcquisitionAtom(&buf, feed, page); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/terms/"`,
+		`<updated>2024-05-01T12:00:00Z</updated>`,
+		`<opensearch:totalResults>40</opensearch:totalResults>`,
+		`<link rel="next" href="https://example.org/opds/books?page=3&amp;topic=poetry" type="` + "application/atom+xml;profile=opds-catalog;kind=acquisition" + `"></link>`,
+		`<link rel="previous" href="https://example.org/opds/books?topic=poetry"`,
+		`<link rel="search" href="https://example.org/opds/search.xml" type="application/opensearchdescription+xml">`,
+		`<id>urn:gutenberg:1342</id>`,
+		`<title>Pride &amp; Prejudice</title>`,
+		`<name>Austen, Jane</name>`,
+		`<dc:language>en</dc:language>`,
+		`<category term="Courtship -- Fiction" label="Courtship -- Fiction"></category>`,
+		`<link rel="http://opds-spec.org/acquisition/open-access" href="https://www.gutenberg.org/ebooks/1342.epub3.images" type="application/epub+zip">`,
+		`<link rel="http://opds-spec.org/image/thumbnail" href="https://www.gutenberg.org/cache/epub/1342/pg1342.cover.small.jpg" type="image/jpeg">`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("feed missing %s", want)
+		}
+	}
+	if strings.Contains(out, `open-access" href="https://www.gutenberg.org/cache`) {
+		t.Errorf("cover must not be listed as an acquisition")
+	}
+	var v struct{}
+	if err := xml.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("feed is not well-formed XML: %v", err)
+	}
+}
+
+func TestWriteAcquisitionJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteAcquisitionJSON(&buf, feed, page); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var v struct {
+		Metadata struct {
+			NumberOfItems int `json:"numberOfItems"`
+		} `json:"metadata"`
+		Links []struct {
+			Rel  string `json:"rel"`
+			Href string `json:"href"`
+		} `json:"links"`
+		Publications []struct {
+			Metadata struct {
+				Identifier string `json:"identifier"`
+				Title      string `json:"title"`
+				Author     []struct {
+					Name string `json:"name"`
+				} `json:"author"`
+			} `json:"metadata"`
+			Links  []struct{ Type string } `json:"links"`
+			Images []struct{ Href string } `json:"images"`
+		} `json:"publications"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if v.Metadata.NumberOfItems != 40 || len(v.Publications) != 1 {
+		t.Fatalf("unexpected feed: %s", buf.String())
+	}
+	pub := v.Publications[0]
+	if pub.Metadata.Identifier != "urn:gutenberg:1342" || pub.Metadata.Title != "Pride & Prejudice" ||
+		pub.Metadata.Author[0].Name != "Austen, Jane" || len(pub.Links) != 2 || len(pub.Images) != 2 {
+		t.Fatalf("unexpected publication: %s", buf.String())
+	}
+	rels := map[string]string{}
+	for _, l := range v.Links {
+		rels[l.Rel] = l.Href
+	}
+	if rels["next"] != "https://example.org/opds/books?page=3&topic=poetry" || rels["self"] != feed.SelfURL {
+		t.Fatalf("unexpected links: %+v", v.Links)
+	}
+}
+
+func TestNavigationFeeds(t *testing.T) {
+	books := []gutendex.Book{
+		{Bookshelves: []string{"Poetry", "Humor"}, Languages: []string{"en"}},
+		{Bookshelves: []string{"Poetry"}, Languages: []string{"fr"}},
+	}
+	entries := NavigationByBookshelf(books, func(s string) string { return "/opds/books?topic=" + s })
+	if len(entries) != 2 || entries[0].Title != "Poetry" || entries[0].Count != 2 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	if langs := NavigationByLanguage(books, func(s string) string { return s }); len(langs) != 2 || langs[0].Title != "en" {
+		t.Fatalf("unexpected language entries: %+v", langs)
+	}
+
+	var atom, js bytes.Buffer
+	if err := WriteNavigationAtom(&atom, feed, entries); err != nil {
+		t.Fatalf("atom: %v", err)
+	}
+	if !strings.Contains(atom.String(), `<link rel="subsection" href="/opds/books?topic=Poetry"`) ||
+		!strings.Contains(atom.String(), `<content type="text">2 books</content>`) {
+		t.Fatalf("unexpected navigation feed:\n%s", atom.String())
+	}
+	if err := WriteNavigationJSON(&js, feed, entries); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	if !strings.Contains(js.String(), `"navigation":[{"rel":"subsection","href":"/opds/books?topic=Poetry","type":"application/opds+json","title":"Poetry"}`) {
+		t.Fatalf("unexpected navigation JSON: %s", js.String())
+	}
+}
+
+func TestWriteOpenSearch(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteOpenSearch(&buf, OpenSearch{ShortName: "Gutenberg", Description: "Search books", Template: "https://example.org/opds/books?search={searchTerms}"})
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<Url type="application/atom+xml;profile=opds-catalog;kind=acquisition" template="https://example.org/opds/books?search={searchTerms}"></Url>`) {
+		t.Fatalf("unexpected description:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,41 @@
+package opds
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// OpenSearch describes the search endpoint advertised to OPDS clients.
+type OpenSearch struct {
+	ShortName   string
+	Description string
+	// Template is the search URL with a {searchTerms} placeholder, e.g.
+	// "https://example.org/opds/books?search={searchTerms}".
+	Template string
+}
+
+type openSearchDescription struct {
+	XMLName       xml.Name `xml:"OpenSearchDescription"`
+	Xmlns         string   `xml:"xmlns,attr"`
+	ShortName     string   `xml:"ShortName"`
+	Description   string   `xml:"Description"`
+	InputEncoding string   `xml:"InputEncoding"`
+	URL           struct {
+		Type     string `xml:"type,attr"`
+		Template string `xml:"template,attr"`
+	} `xml:"Url"`
+}
+
+// WriteOpenSearch renders the OpenSearch description document linked from
+// feeds through Feed.SearchURL.
+func WriteOpenSearch(w io.Writer, s OpenSearch) error {
+	d := openSearchDescription{
+		Xmlns:         "http://a9.com/-/spec/opensearch/1.1/",
+		ShortName:     s.ShortName,
+		Description:   s.Description,
+		InputEncoding: "UTF-8",
+	}
+	d.URL.Type = AcquisitionType
+	d.URL.Template = s.Template
+	return writeXML(w, d)
+}
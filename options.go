@@ -0,0 +1,85 @@
+package gutendex
+
+import (
+	"log/slog"
+	"strings"
+
+	internal "github.com/alex-rs/go-gutendex/internal"
+)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at a Gutendex-compatible endpoint, such as a
+// mirror or a self-hosted server. The default is https://gutendex.com.
+func WithBaseURL(u string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimRight(u, "/") }
+}
+
+// AdaptiveRateLimit bounds and tunes the adaptive rate limiter.
+type AdaptiveRateLimit = internal.AdaptiveConfig
+
+// WithAdaptiveRateLimit replaces the fixed one request per second with an
+// AIMD controller: the rate grows by cfg.Increase after each successful
+// response and is multiplied by cfg.Decrease on 429 or 503 responses or
+// sharply rising latency, always staying within [cfg.Min, cfg.Max]. A
+// cfg.Min that is not positive defaults to one request every ten seconds.
+// Use Client.RateLimit to monitor the current rate.
+func WithAdaptiveRateLimit(cfg AdaptiveRateLimit) Option {
+	return func(c *Client) { c.hc.SetAdaptiveRate(cfg) }
+}
+
+// Observer receives instrumentation events for requests, retries, rate-limit
+// waits, cache hits and decode errors. Implementations must be safe for
+// concurrent use. The metrics package provides an expvar-backed Observer.
+type Observer = internal.Observer
+
+// NopObserver implements Observer with no-op methods. Embed it to observe
+// only some events.
+type NopObserver = internal.NopObserver
+
+// WithObserver reports instrumentation events to o.
+func WithObserver(o Observer) Option {
+	return func(c *Client) { c.hc.Observer = o }
+}
+
+// Tracer starts spans for client operations. Adapt a tracing library such as
+// OpenTelemetry to it to see Gutendex calls in distributed traces.
+type Tracer = internal.Tracer
+
+// Span is an in-progress trace span.
+type Span = internal.Span
+
+// Attribute is a key/value pair attached to a span.
+type Attribute = internal.Attribute
+
+// WithTracer traces client calls with t. GetBook, GetBooks and each listing
+// page fetch get a span, with a child span per HTTP attempt carrying the
+// status code and cache status. The attempt span's traceparent is sent with
+// the request.
+func WithTracer(t Tracer) Option {
+	return func(c *Client) { c.hc.Tracer = t }
+}
+
+// WithLogger logs every request through l: method, URL, status, duration,
+// attempt count, cache hit or miss and time spent waiting on the rate
+// limiter. Request summaries are logged at Info and individual attempts and
+// retries at Debug unless changed with WithLogLevels.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) { c.log.Logger = l }
+}
+
+// WithLogLevels sets the levels of request summaries and of per-attempt
+// messages. Failed requests are logged at Warn or above.
+func WithLogLevels(request, attempt slog.Level) Option {
+	return func(c *Client) {
+		c.log.RequestLevel = request
+		c.log.AttemptLevel = attempt
+	}
+}
+
+// WithRedactedQueries replaces query parameter values in logged URLs, for
+// deployments where search terms must not reach the logs.
+func WithRedactedQueries() Option {
+	return func(c *Client) { c.log.RedactQuery = true }
+}
@@ -0,0 +1,51 @@
+package gutendex
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+func TestWithBaseURLTrimsSlash(t *testing.T) {
+	c := NewClient(WithBaseURL("http://mirror.example/"))
+	if c.baseURL != "http://mirror.example" {
+		t.Fatalf("baseURL = %q", c.baseURL)
+	}
+}
+
+func TestWithLoggerLevels(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":1}`)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := NewClient(WithBaseURL(srv.URL), WithLogger(logger), WithLogLevels(slog.LevelDebug, slog.LevelDebug-4))
+	c.hc.Limiter = rate.NewLimiter(rate.Inf, 1)
+
+	if _, err := c.GetBook(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `level=DEBUG msg="gutendex request"`) || !strings.Contains(out, "status=200") {
+		t.Fatalf("missing request summary:\n%s", out)
+	}
+	if strings.Contains(out, "gutendex attempt") {
+		t.Fatalf("attempt messages should be below the handler level:\n%s", out)
+	}
+}
+
+func TestWithAdaptiveRateLimit(t *testing.T) {
+	c := NewClient(WithAdaptiveRateLimit(AdaptiveRateLimit{Min: 2, Max: 8}))
+	if got := c.RateLimit(); got != 2 {
+		t.Fatalf("RateLimit = %v, want default rate clamped to 2", got)
+	}
+}
@@ -0,0 +1,368 @@
+package gutendex
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	internal "github.com/alex-rs/go-gutendex/internal"
+)
+
+// Project Gutenberg records personal names as "Family, Given" followed by
+// optional titles or generational suffixes, e.g. "Tolstoy, Leo, graf" or
+// "Dana, Richard Henry, Jr.". Initials may be expanded in parentheses, as in
+// "Chesterton, G. K. (Gilbert Keith)", and particles such as "de" or "von"
+// trail the given names. Names without a comma, such as "Homer" or
+// corporate authors, are treated as a family name only.
+
+// personName is a parsed Person.Name.
+type personName struct {
+	family   string
+	given    string
+	expanded string // parenthesized expansion of given, if any
+	particle string
+	prefix   []string // titles shown before the name, e.g. "Sir"
+	titles   []string // titles not shown in the display name, e.g. "graf"
+	suffix   []string // generational suffixes, e.g. "Jr."
+}
+
+var (
+	particles = map[string]bool{
+		"da": true, "de": true, "del": true, "della": true, "der": true,
+		"di": true, "du": true, "la": true, "le": true, "ten": true, "ter": true,
+		"van": true, "von": true, "zu": true,
+	}
+	prefixTitles = map[string]bool{
+		"dame": true, "dr.": true, "lady": true, "lord": true, "miss": true,
+		"mr.": true, "mrs.": true, "rev.": true, "sir": true,
+	}
+	generational = map[string]bool{
+		"jr.": true, "sr.": true, "ii": true, "iii": true, "iv": true,
+	}
+)
+
+func parseName(s string) personName {
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	n := personName{family: parts[0]}
+	if len(parts) == 1 {
+		return n
+	}
+	given := parts[1]
+	if i := strings.IndexByte(given, '('); i >= 0 {
+		n.expanded = strings.Trim(given[i:], "() ")
+		given = strings.TrimSpace(given[:i])
+	}
+	words := strings.Fields(given)
+	end := len(words)
+	for end > 0 && particles[strings.ToLower(words[end-1])] && words[end-1] == strings.ToLower(words[end-1]) {
+		end--
+	}
+	n.given = strings.Join(words[:end], " ")
+	n.particle = strings.Join(words[end:], " ")
+	for _, p := range parts[2:] {
+		switch lower := strings.ToLower(p); {
+		case p == "":
+		case generational[lower]:
+			n.suffix = append(n.suffix, p)
+		case prefixTitles[lower]:
+			n.prefix = append(n.prefix, p)
+		default:
+			n.titles = append(n.titles, p)
+		}
+	}
+	return n
+}
+
+// NameParts holds every part of a parsed Person.Name. Each field matches
+// the Person method of the same name.
+type NameParts struct {
+	Family    string
+	Given     string
+	Particle  string
+	Honorific string
+	Suffix    string
+}
+
+// NameParts parses the name once and returns all of its parts, for callers
+// that need more than one.
+func (p Person) NameParts() NameParts {
+	n := parseName(p.Name)
+	return NameParts{
+		Family:    n.family,
+		Given:     n.given,
+		Particle:  n.particle,
+		Honorific: strings.Join(append(n.prefix, n.titles...), ", "),
+		Suffix:    strings.Join(n.suffix, " "),
+	}
+}
+
+// FamilyName returns the family name, e.g. "Austen" for "Austen, Jane".
+// Names without a comma are returned whole.
+func (p Person) FamilyName() string { return parseName(p.Name).family }
+
+// GivenName returns the given names without particles or parenthesized
+// expansions, e.g. "Mary Wollstonecraft" for "Shelley, Mary Wollstonecraft".
+func (p Person) GivenName() string { return parseName(p.Name).given }
+
+// Particle returns the lowercase particle that trails the given names, e.g.
+// "de" for "Maupassant, Guy de".
+func (p Person) Particle() string { return parseName(p.Name).particle }
+
+// Honorific returns the titles recorded after the name, e.g. "graf" for
+// "Tolstoy, Leo, graf" or "Sir" for "Doyle, Arthur Conan, Sir". Several
+// titles are joined with ", ".
+func (p Person) Honorific() string {
+	n := parseName(p.Name)
+	return strings.Join(append(n.prefix, n.titles...), ", ")
+}
+
+// Suffix returns the generational suffix, e.g. "Jr.".
+func (p Person) Suffix() string { return strings.Join(parseName(p.Name).suffix, " ") }
+
+// DisplayName returns the name in reading order, e.g. "Jane Austen",
+// "Guy de Maupassant" or "Sir Arthur Conan Doyle". Titles such as "graf"
+// are omitted.
+func (p Person) DisplayName() string {
+	n := parseName(p.Name)
+	words := slices.Concat(n.prefix, []string{n.given, n.particle, n.family}, n.suffix)
+	return strings.Join(slices.DeleteFunc(words, func(s string) bool { return s == "" }), " ")
+}
+
+// SortName returns the name in "Family, Given particle[, suffix]" order
+// without titles or expansions, e.g. "Maupassant, Guy de". Compare sort
+// names case-insensitively.
+func (p Person) SortName() string {
+	n := parseName(p.Name)
+	if n.given == "" && n.particle == "" {
+		return n.family
+	}
+	s := n.family + ", " + strings.TrimSpace(n.given+" "+n.particle)
+	if len(n.suffix) > 0 {
+		s += ", " + strings.Join(n.suffix, " ")
+	}
+	return s
+}
+
+// LifeSpan formats the birth and death years as "1775-1817", using "?" for
+// an unknown year and a " BCE" suffix for negative years, e.g. "750 BCE-?".
+// It returns "" when both years are unknown.
+func (p Person) LifeSpan() string {
+	if p.BirthYear == nil && p.DeathYear == nil {
+		return ""
+	}
+	return formatYear(p.BirthYear) + "-" + formatYear(p.DeathYear)
+}
+
+func formatYear(y *int) string {
+	switch {
+	case y == nil:
+		return "?"
+	case *y < 0:
+		return strconv.Itoa(-*y) + " BCE"
+	default:
+		return strconv.Itoa(*y)
+	}
+}
+
+// SamePerson reports whether a and b are likely the same person spelled
+// differently. Family names must match after folding case and accents,
+// allowing a small edit distance ("Dostoyevsky" and "Dostoevsky"). Given
+// names must agree word by word, where an initial matches any name starting
+// with it and parenthesized expansions are taken into account. Known birth
+// or death years must not differ.
+func SamePerson(a, b Person) bool {
+	if !sameYear(a.BirthYear, b.BirthYear) || !sameYear(a.DeathYear, b.DeathYear) {
+		return false
+	}
+	na, nb := parseName(a.Name), parseName(b.Name)
+	fa, fb := foldName(na.family), foldName(nb.family)
+	if fa == "" || editDistance(fa, fb) > max(len(fa), len(fb))/6 {
+		return false
+	}
+	return sameGiven(givenWords(na), givenWords(nb))
+}
+
+func sameYear(a, b *int) bool { return a == nil || b == nil || *a == *b }
+
+// givenWords returns the folded given names, preferring the expansion.
+func givenWords(n personName) []string {
+	given := n.given
+	if n.expanded != "" {
+		given = n.expanded
+	}
+	var out []string
+	for _, w := range strings.FieldsFunc(given, func(r rune) bool { return r == ' ' || r == '.' || r == '-' }) {
+		if f := foldName(w); f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// sameGiven reports whether the shorter list of given names is compatible
+// with the start of the longer one, so that "Mary" matches "Mary
+// Wollstonecraft" and "G K" matches "Gilbert Keith".
+func sameGiven(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	for i, w := range a {
+		v := b[i]
+		switch {
+		case len(w) == 1 || len(v) == 1:
+			if w[0] != v[0] {
+				return false
+			}
+		case editDistance(w, v) > max(len(w), len(v))/6:
+			return false
+		}
+	}
+	return true
+}
+
+// foldName lowercases s, folds common accented letters and drops anything
+// that is not a letter or digit.
+func foldName(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if f, ok := internal.FoldRune(r); ok {
+			sb.WriteString(f)
+		} else if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// editDistance returns the Levenshtein distance between a and b in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// PersonMatcher groups variant spellings of the same person, as decided by
+// SamePerson. The zero value is ready to use. It is not safe for concurrent
+// use.
+type PersonMatcher struct {
+	groups []*personGroup
+	// byInitial indexes groups by the first letter of the folded family
+	// name to keep lookups cheap on large catalogs.
+	byInitial map[byte][]*personGroup
+}
+
+type personGroup struct {
+	members []Person
+	best    Person
+}
+
+// Add records p and returns the canonical form of its group.
+func (m *PersonMatcher) Add(p Person) Person { return m.add(p).best }
+
+func (m *PersonMatcher) add(p Person) *personGroup {
+	if g := m.find(p); g != nil {
+		g.members = append(g.members, p)
+		g.best = canonical(g.members)
+		return g
+	}
+	g := &personGroup{members: []Person{p}, best: canonical([]Person{p})}
+	m.groups = append(m.groups, g)
+	if m.byInitial == nil {
+		m.byInitial = make(map[byte][]*personGroup)
+	}
+	k := initial(p)
+	m.byInitial[k] = append(m.byInitial[k], g)
+	return g
+}
+
+// Canonical returns the canonical form of the group p belongs to, or p
+// itself if it matches no recorded person. It does not record p.
+func (m *PersonMatcher) Canonical(p Person) Person {
+	if g := m.find(p); g != nil {
+		return g.best
+	}
+	return p
+}
+
+// Groups returns the recorded people grouped by identity, in the order the
+// groups were first seen.
+func (m *PersonMatcher) Groups() [][]Person {
+	out := make([][]Person, len(m.groups))
+	for i, g := range m.groups {
+		out[i] = slices.Clone(g.members)
+	}
+	return out
+}
+
+func (m *PersonMatcher) find(p Person) *personGroup {
+	for _, g := range m.byInitial[initial(p)] {
+		for _, q := range g.members {
+			if SamePerson(p, q) {
+				return g
+			}
+		}
+	}
+	return nil
+}
+
+func initial(p Person) byte {
+	if f := foldName(parseName(p.Name).family); f != "" {
+		return f[0]
+	}
+	return 0
+}
+
+// canonical picks the most complete spelling in a group: the one with the
+// most known years, then the longest given names, then the first seen.
+// Years missing from it are filled in from the other members.
+func canonical(members []Person) Person {
+	score := func(p Person) (int, int) {
+		years := 0
+		if p.BirthYear != nil {
+			years++
+		}
+		if p.DeathYear != nil {
+			years++
+		}
+		n := parseName(p.Name)
+		return years, len(n.given) + len(n.expanded)
+	}
+	best := members[0]
+	for _, p := range members[1:] {
+		by, bg := score(best)
+		py, pg := score(p)
+		if py > by || py == by && pg > bg {
+			best = p
+		}
+	}
+	for _, p := range members {
+		if best.BirthYear == nil {
+			best.BirthYear = p.BirthYear
+		}
+		if best.DeathYear == nil {
+			best.DeathYear = p.DeathYear
+		}
+	}
+	return best
+}
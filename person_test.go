@@ -0,0 +1,100 @@
+package gutendex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func year(n int) *int { return &n }
+
+func TestPersonNames(t *testing.T) {
+	tests := []struct {
+		name                               string
+		family, given, particle, honorific string
+		suffix, display, sortName          string
+	}{
+		{"Austen, Jane", "Austen", "Jane", "", "", "", "Jane Austen", "Austen, Jane"},
+		{"Tolstoy, Leo, graf", "Tolstoy", "Leo", "", "graf", "", "Leo Tolstoy", "Tolstoy, Leo"},
+		{"Shelley, Mary Wollstonecraft", "Shelley", "Mary Wollstonecraft", "", "", "", "Mary Wollstonecraft Shelley", "Shelley, Mary Wollstonecraft"},
+		{"Doyle, Arthur Conan, Sir", "Doyle", "Arthur Conan", "", "Sir", "", "Sir Arthur Conan Doyle", "Doyle, Arthur Conan"},
+		{"Maupassant, Guy de", "Maupassant", "Guy", "de", "", "", "Guy de Maupassant", "Maupassant, Guy de"},
+		{"Chesterton, G. K. (Gilbert Keith)", "Chesterton", "G. K.", "", "", "", "G. K. Chesterton", "Chesterton, G. K."},
+		{"Dana, Richard Henry, Jr.", "Dana", "Richard Henry", "", "", "Jr.", "Richard Henry Dana Jr.", "Dana, Richard Henry, Jr."},
+		{"Homer", "Homer", "", "", "", "", "Homer", "Homer"},
+	}
+	for _, tt := range tests {
+		p := Person{Name: tt.name}
+		got := []string{p.FamilyName(), p.GivenName(), p.Particle(), p.Honorific(), p.Suffix(), p.DisplayName(), p.SortName()}
+		want := []string{tt.family, tt.given, tt.particle, tt.honorific, tt.suffix, tt.display, tt.sortName}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %q, want %q", tt.name, got, want)
+		}
+		parts := NameParts{Family: tt.family, Given: tt.given, Particle: tt.particle, Honorific: tt.honorific, Suffix: tt.suffix}
+		if got := p.NameParts(); got != parts {
+			t.Errorf("%q: NameParts() = %+v, want %+v", tt.name, got, parts)
+		}
+	}
+}
+
+func TestLifeSpan(t *testing.T) {
+	tests := []struct {
+		p    Person
+		want string
+	}{
+		{Person{BirthYear: year(1775), DeathYear: year(1817)}, "1775-1817"},
+		{Person{BirthYear: year(-750)}, "750 BCE-?"},
+		{Person{BirthYear: year(-4), DeathYear: year(65)}, "4 BCE-65"},
+		{Person{DeathYear: year(1900)}, "?-1900"},
+		{Person{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.p.LifeSpan(); got != tt.want {
+			t.Errorf("LifeSpan() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestSamePerson(t *testing.T) {
+	tests := []struct {
+		a, b Person
+		want bool
+	}{
+		{Person{Name: "Dostoyevsky, Fyodor"}, Person{Name: "Dostoevsky, Fyodor"}, true},
+		{Person{Name: "Chesterton, G. K. (Gilbert Keith)"}, Person{Name: "Chesterton, Gilbert Keith"}, true},
+		{Person{Name: "Chesterton, G. K."}, Person{Name: "Chesterton, Gilbert Keith"}, true},
+		{Person{Name: "Tolstoy, Leo, graf"}, Person{Name: "Tolstoi, Leo"}, true},
+		{Person{Name: "Shelley, Mary"}, Person{Name: "Shelley, Mary Wollstonecraft"}, true},
+		{Person{Name: "Molière"}, Person{Name: "Moliere"}, true},
+		{Person{Name: "Shelley, Mary Wollstonecraft"}, Person{Name: "Shelley, Percy Bysshe"}, false},
+		{Person{Name: "Austen, Jane"}, Person{Name: "Auden, Jane"}, false},
+		{Person{Name: "Dumas, Alexandre", BirthYear: year(1802)}, Person{Name: "Dumas, Alexandre", BirthYear: year(1824)}, false},
+		{Person{Name: "Homer"}, Person{Name: "Homer, Winslow"}, false},
+	}
+	for _, tt := range tests {
+		if got := SamePerson(tt.a, tt.b); got != tt.want {
+			t.Errorf("SamePerson(%q, %q) = %v, want %v", tt.a.Name, tt.b.Name, got, tt.want)
+		}
+	}
+}
+
+func TestPersonMatcher(t *testing.T) {
+	var m PersonMatcher
+	m.Add(Person{Name: "Chesterton, G. K."})
+	m.Add(Person{Name: "Dumas, Alexandre", BirthYear: year(1802), DeathYear: year(1870)})
+	m.Add(Person{Name: "Dumas, Alexandre", BirthYear: year(1824), DeathYear: year(1895)})
+	got := m.Add(Person{Name: "Chesterton, G. K. (Gilbert Keith)", BirthYear: year(1874)})
+	want := Person{Name: "Chesterton, G. K. (Gilbert Keith)", BirthYear: year(1874)}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Add = %+v, want %+v", got, want)
+	}
+	if got := m.Canonical(Person{Name: "Chesterton, Gilbert K."}); got.Name != want.Name {
+		t.Fatalf("Canonical = %+v", got)
+	}
+	if got := m.Canonical(Person{Name: "Wilde, Oscar"}); got.Name != "Wilde, Oscar" {
+		t.Fatalf("unmatched Canonical = %+v", got)
+	}
+	groups := m.Groups()
+	if len(groups) != 3 || len(groups[0]) != 2 {
+		t.Fatalf("groups = %+v", groups)
+	}
+}
@@ -0,0 +1,202 @@
+// Package popularity records download counts over time and reports how
+// books move between recordings.
+//
+// Book.DownloadCount only covers the 30 days before it was fetched, so
+// trends need repeated snapshots. A Recorder takes snapshots of the most
+// popular books and of a watchlist and appends them to a Store; Compare
+// and Trending turn two snapshots into rank changes and growth rates.
+package popularity
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// Snapshot holds the download counts of a set of books at one time.
+type Snapshot struct {
+	Taken   time.Time `json:"taken"`
+	Entries []Entry   `json:"entries"`
+}
+
+// Entry is one book of a snapshot.
+type Entry struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title,omitempty"`
+	Downloads int    `json:"downloads"`
+	// Rank is the book's position in the popularity listing, counting
+	// from 1, or 0 for watchlist books outside the recorded top.
+	Rank int `json:"rank,omitempty"`
+}
+
+// Recorder takes snapshots from a source.
+type Recorder struct {
+	Source gutendex.BookSource
+	// Store, when set, receives every snapshot taken by Record and Run.
+	Store *Store
+	// Top is the number of most popular books to record.
+	Top int
+	// Watchlist lists further books to record wherever they rank.
+	Watchlist []int
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// Record takes a snapshot of the top books and the watchlist and appends
+// it to the store.
+func (r *Recorder) Record(ctx context.Context) (Snapshot, error) {
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	snap := Snapshot{Taken: now().UTC()}
+	seen := make(map[int]bool)
+	if r.Top > 0 {
+		top, err := r.Source.ListBooks(gutendex.Query{Sort: gutendex.SortPopular}).Take(r.Top).Collect(ctx)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		for i, b := range top {
+			snap.Entries = append(snap.Entries, Entry{ID: b.ID, Title: b.Title, Downloads: b.DownloadCount, Rank: i + 1})
+			seen[b.ID] = true
+		}
+	}
+	var missing []int
+	for _, id := range r.Watchlist {
+		if !seen[id] {
+			missing = append(missing, id)
+			seen[id] = true
+		}
+	}
+	if len(missing) > 0 {
+		books, err := r.Source.GetBooks(ctx, missing)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		for _, b := range books {
+			snap.Entries = append(snap.Entries, Entry{ID: b.ID, Title: b.Title, Downloads: b.DownloadCount})
+		}
+	}
+	if r.Store != nil {
+		if err := r.Store.Append(snap); err != nil {
+			return Snapshot{}, err
+		}
+	}
+	return snap, nil
+}
+
+// Run records a snapshot immediately and then every interval until ctx
+// ends, returning the first error.
+func (r *Recorder) Run(ctx context.Context, interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if _, err := r.Record(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Change describes how one book moved between two snapshots.
+type Change struct {
+	ID    int
+	Title string
+	// Downloads and PrevDownloads are the counts in the later and earlier
+	// snapshot.
+	Downloads, PrevDownloads int
+	// Rank and PrevRank are the ranks in the later and earlier snapshot,
+	// 0 when the book was not ranked.
+	Rank, PrevRank int
+	// RankDelta is how many places the book climbed; negative values mean
+	// it fell. It is 0 unless the book was ranked in both snapshots.
+	RankDelta int
+	// Growth is the relative change in downloads, e.g. 0.5 for +50%. It is
+	// 0 unless the book is in both snapshots with earlier downloads.
+	Growth float64
+	// New marks books missing from the earlier snapshot, Dropped books
+	// missing from the later one.
+	New, Dropped bool
+}
+
+// Compare returns the changes from prev to cur, ordered by rank in cur,
+// then by downloads, with dropped books last.
+func Compare(prev, cur Snapshot) []Change {
+	before := make(map[int]Entry, len(prev.Entries))
+	for _, e := range prev.Entries {
+		before[e.ID] = e
+	}
+	var out []Change
+	for _, e := range cur.Entries {
+		c := Change{ID: e.ID, Title: e.Title, Downloads: e.Downloads, Rank: e.Rank}
+		p, ok := before[e.ID]
+		if !ok {
+			c.New = true
+		} else {
+			delete(before, e.ID)
+			c.PrevDownloads, c.PrevRank = p.Downloads, p.Rank
+			if c.Rank > 0 && c.PrevRank > 0 {
+				c.RankDelta = c.PrevRank - c.Rank
+			}
+			if p.Downloads > 0 {
+				c.Growth = float64(e.Downloads-p.Downloads) / float64(p.Downloads)
+			}
+		}
+		out = append(out, c)
+	}
+	for _, p := range prev.Entries {
+		if _, ok := before[p.ID]; ok {
+			out = append(out, Change{ID: p.ID, Title: p.Title, PrevDownloads: p.Downloads, PrevRank: p.Rank, Dropped: true})
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Change) int {
+		if a.Dropped != b.Dropped {
+			if a.Dropped {
+				return 1
+			}
+			return -1
+		}
+		if c := cmp.Compare(rankKey(a.Rank), rankKey(b.Rank)); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Downloads, a.Downloads)
+	})
+	return out
+}
+
+// rankKey orders unranked books after ranked ones.
+func rankKey(rank int) int {
+	if rank == 0 {
+		return int(^uint(0) >> 1)
+	}
+	return rank
+}
+
+// Trending returns up to n books present in both snapshots with at least
+// minDownloads earlier downloads, fastest growing first. The floor keeps
+// rarely read books, whose counts swing widely, off the list.
+func Trending(prev, cur Snapshot, n, minDownloads int) []Change {
+	var out []Change
+	for _, c := range Compare(prev, cur) {
+		if !c.New && !c.Dropped && c.PrevDownloads >= max(minDownloads, 1) {
+			out = append(out, c)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Change) int {
+		if c := cmp.Compare(b.Growth, a.Growth); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.RankDelta, a.RankDelta)
+	})
+	if n >= 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
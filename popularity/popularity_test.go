@@ -0,0 +1,190 @@
+package popularity
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+func catalog(downloads map[int]int) *gutendex.Catalog {
+	var books []gutendex.Book
+	for id, n := range downloads {
+		books = append(books, gutendex.Book{ID: id, Title: "Book " + string(rune('A'+id)), DownloadCount: n})
+	}
+	return gutendex.NewCatalog(books...)
+}
+
+func TestRecordTopAndWatchlist(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	store := Open(filepath.Join(t.TempDir(), "pop.jsonl"))
+	r := &Recorder{
+		Source:    catalog(map[int]int{1: 500, 2: 300, 3: 100, 4: 50}),
+		Store:     store,
+		Top:       2,
+		Watchlist: []int{4, 1},
+		Now:       func() time.Time { return at },
+	}
+	snap, err := r.Record(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{{1, "Book B", 500, 1}, {2, "Book C", 300, 2}, {4, "Book E", 50, 0}}
+	if len(snap.Entries) != len(want) {
+		t.Fatalf("entries = %+v", snap.Entries)
+	}
+	for i, e := range want {
+		if snap.Entries[i] != e {
+			t.Errorf("entry %d = %+v, want %+v", i, snap.Entries[i], e)
+		}
+	}
+	got, err := store.Snapshots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !got[0].Taken.Equal(at) || len(got[0].Entries) != 3 {
+		t.Fatalf("stored = %+v", got)
+	}
+}
+
+func TestStoreAppendAndLatest(t *testing.T) {
+	store := Open(filepath.Join(t.TempDir(), "pop.jsonl"))
+	if snaps, err := store.Snapshots(); err != nil || snaps != nil {
+		t.Fatalf("missing file = %v, %v", snaps, err)
+	}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		if err := store.Append(Snapshot{Taken: base.AddDate(0, 0, i), Entries: []Entry{{ID: i}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	last, err := store.Latest(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(last) != 2 || last[0].Entries[0].ID != 1 || last[1].Entries[0].ID != 2 {
+		t.Fatalf("Latest(2) = %+v", last)
+	}
+}
+
+func TestStoreTruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pop.jsonl")
+	store := Open(path)
+	if err := store.Append(Snapshot{Entries: []Entry{{ID: 1}}}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"taken":"2024-`); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	snaps, err := store.Snapshots()
+	if err != nil || len(snaps) != 1 {
+		t.Fatalf("Snapshots = %+v, %v", snaps, err)
+	}
+
+	// Appending after the crash drops the partial line.
+	for id := 2; id <= 3; id++ {
+		if err := store.Append(Snapshot{Entries: []Entry{{ID: id}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snaps, err = store.Snapshots()
+	if err != nil || len(snaps) != 3 || snaps[1].Entries[0].ID != 2 || snaps[2].Entries[0].ID != 3 {
+		t.Fatalf("Snapshots after Append = %+v, %v", snaps, err)
+	}
+
+	// A file holding only a partial line is emptied.
+	if err := os.WriteFile(path, []byte(`{"taken":`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append(Snapshot{Entries: []Entry{{ID: 4}}}); err != nil {
+		t.Fatal(err)
+	}
+	if snaps, err = store.Snapshots(); err != nil || len(snaps) != 1 || snaps[0].Entries[0].ID != 4 {
+		t.Fatalf("Snapshots = %+v, %v", snaps, err)
+	}
+
+	// A bad line followed by more snapshots is corruption, not truncation.
+	if err := os.WriteFile(path, []byte("{bad\n{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Snapshots(); err == nil {
+		t.Fatal("expected error for corrupt line")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	prev := Snapshot{Entries: []Entry{
+		{ID: 1, Downloads: 1000, Rank: 1},
+		{ID: 2, Downloads: 400, Rank: 2},
+		{ID: 3, Downloads: 200, Rank: 3},
+		{ID: 9, Downloads: 10},
+	}}
+	cur := Snapshot{Entries: []Entry{
+		{ID: 2, Downloads: 1200, Rank: 1},
+		{ID: 1, Downloads: 900, Rank: 2},
+		{ID: 4, Downloads: 300, Rank: 3},
+		{ID: 9, Downloads: 40},
+	}}
+	changes := Compare(prev, cur)
+	ids := make([]int, len(changes))
+	for i, c := range changes {
+		ids[i] = c.ID
+	}
+	if want := []int{2, 1, 4, 9, 3}; !slices.Equal(ids, want) {
+		t.Fatalf("order = %v, want %v", ids, want)
+	}
+	if c := changes[0]; c.RankDelta != 1 || c.Growth != 2 || c.PrevDownloads != 400 {
+		t.Errorf("book 2 = %+v", c)
+	}
+	if c := changes[1]; c.RankDelta != -1 || c.Growth != -0.1 {
+		t.Errorf("book 1 = %+v", c)
+	}
+	if c := changes[2]; !c.New || c.Growth != 0 {
+		t.Errorf("book 4 = %+v", c)
+	}
+	if c := changes[3]; c.RankDelta != 0 || c.Growth != 3 {
+		t.Errorf("book 9 = %+v", c)
+	}
+	if c := changes[4]; !c.Dropped || c.PrevRank != 3 {
+		t.Errorf("book 3 = %+v", c)
+	}
+}
+
+func TestTrending(t *testing.T) {
+	prev := Snapshot{Entries: []Entry{
+		{ID: 1, Downloads: 1000, Rank: 1},
+		{ID: 2, Downloads: 400, Rank: 2},
+		{ID: 9, Downloads: 10},
+	}}
+	cur := Snapshot{Entries: []Entry{
+		{ID: 2, Downloads: 1200, Rank: 1},
+		{ID: 1, Downloads: 1100, Rank: 2},
+		{ID: 9, Downloads: 40},
+		{ID: 5, Downloads: 5000},
+	}}
+	var ids []int
+	for _, c := range Trending(prev, cur, 10, 100) {
+		ids = append(ids, c.ID)
+	}
+	if want := []int{2, 1}; !slices.Equal(ids, want) {
+		t.Fatalf("Trending = %v, want %v", ids, want)
+	}
+	ids = ids[:0]
+	for _, c := range Trending(prev, cur, 1, 0) {
+		ids = append(ids, c.ID)
+	}
+	if want := []int{9}; !slices.Equal(ids, want) {
+		t.Fatalf("Trending without floor = %v, want %v", ids, want)
+	}
+}
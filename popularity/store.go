@@ -0,0 +1,122 @@
+package popularity
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Store is an append-only file of snapshots, one JSON object per line.
+// Snapshots are never rewritten, so a crash during Append loses at most
+// the snapshot being written; the next Append removes its partial line.
+// A Store is not safe for use by several processes at once.
+type Store struct {
+	path string
+}
+
+// Open returns the store kept in the file at path. The file is created by
+// the first Append.
+func Open(path string) *Store {
+	return &Store{path: path}
+}
+
+// Append adds snap to the end of the store.
+func (s *Store) Append(snap Snapshot) error {
+	line, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := trimPartialLine(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// trimPartialLine truncates f after its last newline, removing a line
+// left unfinished by an interrupted Append.
+func trimPartialLine(f *os.File) error {
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	end := fi.Size()
+	buf := make([]byte, 4096)
+	for off := end; off > 0; {
+		n := min(off, int64(len(buf)))
+		off -= n
+		if _, err := f.ReadAt(buf[:n], off); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			if keep := off + int64(i) + 1; keep < end {
+				return f.Truncate(keep)
+			}
+			return nil
+		}
+	}
+	if end > 0 {
+		return f.Truncate(0)
+	}
+	return nil
+}
+
+// Snapshots returns every snapshot in the store, oldest first. A missing
+// file holds no snapshots. A truncated last line, left by an interrupted
+// Append, is ignored.
+func (s *Store) Snapshots() ([]Snapshot, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	var out []Snapshot
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 64<<20)
+	var bad error
+	for n := 1; sc.Scan(); n++ {
+		if bad != nil {
+			return nil, bad
+		}
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var snap Snapshot
+		if err := json.Unmarshal(sc.Bytes(), &snap); err != nil {
+			bad = fmt.Errorf("popularity: %s line %d: %w", s.path, n, err)
+			continue
+		}
+		out = append(out, snap)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Latest returns the last n snapshots in the store, oldest first.
+func (s *Store) Latest(n int) ([]Snapshot, error) {
+	all, err := s.Snapshots()
+	if err != nil {
+		return nil, err
+	}
+	if len(all) > n {
+		all = all[len(all)-n:]
+	}
+	return all, nil
+}
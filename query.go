@@ -1,37 +1,268 @@
 package gutendex
 
-import "net/url"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alex-rs/go-gutendex/language"
+)
+
+// SortOrder selects the ordering of listing results.
+type SortOrder string
+
+const (
+	// SortPopular orders books by download count, most popular first. It is
+	// the API default.
+	SortPopular SortOrder = "popular"
+	// SortAscending orders books by ID, lowest first.
+	SortAscending SortOrder = "ascending"
+	// SortDescending orders books by ID, highest (newest) first.
+	SortDescending SortOrder = "descending"
+)
 
 // Query describes filters for listing books.
 type Query struct {
-	Author   string
-	Title    string
-	Topic    string
+	Author string
+	Title  string
+	// Search matches books whose title or author names contain every word.
+	Search string
+	Topic  string
+	// Language holds one or more comma-separated language codes.
 	Language string
-	MIME     string
+	// Languages adds further language codes. Books in any of the languages
+	// of Language and Languages match.
+	Languages []string
+	MIME      string
+	// Copyright, when set, keeps only books whose copyright flag has this
+	// value; false selects public domain books.
+	Copyright *bool
+	Sort      SortOrder
+	// SeparateSearch sends Author and Title as separate filters even when
+	// both are set. By default they are combined into one search, which
+	// matches books whose title or author names contain every word.
+	SeparateSearch bool
 }
 
 // Values converts the query into URL values compatible with Gutendex.
 func (q Query) Values() url.Values {
 	v := url.Values{}
-	if q.Author != "" && q.Title != "" {
-		v.Set("search", q.Author+" "+q.Title)
-	} else {
-		if q.Author != "" {
-			v.Set("author", q.Author)
-		}
-		if q.Title != "" {
-			v.Set("title", q.Title)
-		}
+	author, title, search := q.Author, q.Title, q.Search
+	if author != "" && title != "" && !q.SeparateSearch {
+		search = strings.TrimSpace(search + " " + author + " " + title)
+		author, title = "", ""
+	}
+	if search != "" {
+		v.Set("search", search)
+	}
+	if author != "" {
+		v.Set("author", author)
+	}
+	if title != "" {
+		v.Set("title", title)
 	}
 	if q.Topic != "" {
 		v.Set("topic", q.Topic)
 	}
-	if q.Language != "" {
-		v.Set("languages", q.Language)
+	if codes := q.languageCodes(); len(codes) > 0 {
+		v.Set("languages", strings.Join(codes, ","))
 	}
 	if q.MIME != "" {
 		v.Set("mime_type", q.MIME)
 	}
+	if q.Copyright != nil {
+		v.Set("copyright", strconv.FormatBool(*q.Copyright))
+	}
+	if q.Sort != "" {
+		v.Set("sort", string(q.Sort))
+	}
 	return v
 }
+
+// ParseQuery is the inverse of Query.Values, for handlers that accept
+// Gutendex-style parameters. Parameters it does not know, such as "page",
+// are ignored. The result is validated.
+func ParseQuery(v url.Values) (Query, error) {
+	q := Query{
+		Author:         v.Get("author"),
+		Title:          v.Get("title"),
+		Search:         v.Get("search"),
+		Topic:          v.Get("topic"),
+		MIME:           v.Get("mime_type"),
+		Sort:           SortOrder(v.Get("sort")),
+		SeparateSearch: v.Has("author") && v.Has("title"),
+	}
+	if s := v.Get("languages"); s != "" {
+		q.Languages = strings.Split(s, ",")
+	}
+	if s := v.Get("copyright"); s != "" {
+		c, err := strconv.ParseBool(s)
+		if err != nil {
+			return q, &Error{Op: "ParseQuery", Kind: ErrInvalidQuery, Err: fmt.Errorf("copyright %q is not true or false", s)}
+		}
+		q.Copyright = &c
+	}
+	if err := q.Validate(); err != nil {
+		return q, err
+	}
+	return q, nil
+}
+
+// languageCodes returns the normalized, distinct codes of Language and
+// Languages in order of appearance.
+func (q Query) languageCodes() []string {
+	var codes []string
+	for _, c := range append(strings.Split(q.Language, ","), q.Languages...) {
+		if c = language.Normalize(c); c != "" && !slices.Contains(codes, c) {
+			codes = append(codes, c)
+		}
+	}
+	return codes
+}
+
+// Validate reports every malformed filter of q: unknown language codes,
+// an unknown sort order, a MIME type that is not of the form "type" or
+// "type/subtype", and filters consisting only of white space. Listing
+// methods call it before sending a request. The error has kind
+// ErrInvalidQuery.
+func (q Query) Validate() error {
+	var problems []error
+	for _, f := range []struct{ name, value string }{
+		{"author", q.Author}, {"title", q.Title}, {"search", q.Search}, {"topic", q.Topic}, {"mime_type", q.MIME},
+	} {
+		if f.value != "" && strings.TrimSpace(f.value) == "" {
+			problems = append(problems, fmt.Errorf("%s is blank", f.name))
+		}
+	}
+	for _, c := range q.languageCodes() {
+		if !language.Valid(c) {
+			problems = append(problems, fmt.Errorf("unknown language code %q", c))
+		}
+	}
+	if q.MIME != "" && !mimePrefix.MatchString(q.MIME) {
+		problems = append(problems, fmt.Errorf("malformed MIME type %q", q.MIME))
+	}
+	switch q.Sort {
+	case "", SortPopular, SortAscending, SortDescending:
+	default:
+		problems = append(problems, fmt.Errorf("unknown sort order %q", q.Sort))
+	}
+	if len(problems) > 0 {
+		return &Error{Op: "Query", Kind: ErrInvalidQuery, Err: errors.Join(problems...)}
+	}
+	return nil
+}
+
+// mimePrefix matches a MIME type or type prefix such as "text", "text/",
+// "text/html" or "text/plain; charset=utf-8".
+var mimePrefix = regexp.MustCompile(`^[a-z]+(/[a-z0-9.+-]*(;.*)?)?$`)
+
+// sort reorders books, which must already be ordered by popularity,
+// according to the query's sort order.
+func (q Query) sort(books []Book) {
+	switch q.Sort {
+	case SortAscending:
+		slices.SortFunc(books, func(a, b Book) int { return cmp.Compare(a.ID, b.ID) })
+	case SortDescending:
+		slices.SortFunc(books, func(a, b Book) int { return cmp.Compare(b.ID, a.ID) })
+	}
+}
+
+// Match reports whether b satisfies the query, following the semantics the
+// remote API applies to the parameters produced by Values. Like the API, it
+// does not validate q: an unknown language code or MIME type simply
+// matches no book.
+func (q Query) Match(b Book) bool {
+	if q.Search != "" && !matchSearch(b, q.Search) {
+		return false
+	}
+	if q.Author != "" && q.Title != "" && !q.SeparateSearch {
+		if !matchSearch(b, q.Author+" "+q.Title) {
+			return false
+		}
+	} else {
+		if q.Author != "" && !matchAuthor(b, q.Author) {
+			return false
+		}
+		if q.Title != "" && !containsFold(b.Title, q.Title) {
+			return false
+		}
+	}
+	if q.Topic != "" && !matchTopic(b, q.Topic) {
+		return false
+	}
+	if codes := q.languageCodes(); len(codes) > 0 && !matchLanguages(b, codes) {
+		return false
+	}
+	if q.MIME != "" && !matchMIME(b, q.MIME) {
+		return false
+	}
+	if q.Copyright != nil && (b.Copyright == nil || *b.Copyright != *q.Copyright) {
+		return false
+	}
+	return true
+}
+
+// matchSearch reports whether every word of terms occurs in the title or an
+// author name of b.
+func matchSearch(b Book, terms string) bool {
+	for _, w := range strings.Fields(terms) {
+		if containsFold(b.Title, w) || matchAuthor(b, w) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func matchAuthor(b Book, name string) bool {
+	for _, p := range b.Authors {
+		if containsFold(p.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchTopic(b Book, topic string) bool {
+	for _, s := range b.Subjects {
+		if containsFold(s, topic) {
+			return true
+		}
+	}
+	for _, s := range b.Bookshelves {
+		if containsFold(s, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchLanguages(b Book, codes []string) bool {
+	for _, code := range codes {
+		for _, l := range b.Languages {
+			if strings.EqualFold(l, code) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchMIME(b Book, prefix string) bool {
+	for mime := range b.Formats {
+		if strings.HasPrefix(mime, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -1,9 +1,11 @@
 package gutendex
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"testing"
 )
 
@@ -33,6 +35,16 @@ func TestQueryValues(t *testing.T) {
 			q:    Query{Topic: "top", Language: "en", MIME: "text"},
 			want: url.Values{"topic": {"top"}, "languages": {"en"}, "mime_type": {"text"}},
 		},
+		{
+			name: "languages merged and normalized",
+			q:    Query{Language: "en, FR", Languages: []string{"fr", "grc"}},
+			want: url.Values{"languages": {"en,fr,grc"}},
+		},
+		{
+			name: "sort",
+			q:    Query{Sort: SortDescending},
+			want: url.Values{"sort": {"descending"}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -70,3 +82,114 @@ func TestListBooksAndSearchURLs(t *testing.T) {
 		t.Fatalf("Search URL = %s", got)
 	}
 }
+
+func TestInvalidLanguageQuery(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"count":0,"next":null,"previous":null,"results":[]}`))
+	}))
+	defer srv.Close()
+	c := newTestClient(srv.URL)
+
+	q := Query{Languages: []string{"en", "xx"}}
+	it := c.ListBooks(q)
+	if it.Next() || !IsInvalidQuery(it.Err()) {
+		t.Fatalf("ListBooks err = %v", it.Err())
+	}
+	if _, err := c.GetPage(context.Background(), q, 1); !IsInvalidQuery(err) {
+		t.Fatalf("GetPage err = %v", err)
+	}
+	if it := NewCatalog(testBooks()...).ListBooks(q); it.Next() || !IsInvalidQuery(it.Err()) {
+		t.Fatalf("Catalog.ListBooks err = %v", it.Err())
+	}
+	if requests != 0 {
+		t.Fatalf("made %d requests for an invalid query", requests)
+	}
+}
+
+func TestCatalogLanguages(t *testing.T) {
+	c := NewCatalog(testBooks()...)
+	if got := collectIDs(t, c.ListBooks(Query{Languages: []string{"FR"}})); len(got) != 1 || got[0] != 3 {
+		t.Fatalf("ids = %v", got)
+	}
+	if got := collectIDs(t, c.ListBooks(Query{Language: "de", Languages: []string{"en"}})); len(got) != 2 {
+		t.Fatalf("ids = %v", got)
+	}
+}
+
+func TestLanguageNames(t *testing.T) {
+	b := Book{Languages: []string{"fr", "grc", "zz"}}
+	got := b.LanguageNames()
+	if want := []string{"French", "Ancient Greek", "zz"}; !slices.Equal(got, want) {
+		t.Fatalf("LanguageNames() = %q, want %q", got, want)
+	}
+}
+
+func TestParseQueryRoundTrip(t *testing.T) {
+	pd := false
+	for _, q := range []Query{
+		{Search: "verne", Topic: "adventure", Languages: []string{"en", "fr"}, MIME: "text/html", Copyright: &pd, Sort: SortDescending},
+		{Author: "a", Title: "t", SeparateSearch: true},
+		{Author: "a", Title: "t"},
+		{},
+	} {
+		parsed, err := ParseQuery(q.Values())
+		if err != nil {
+			t.Fatalf("ParseQuery(%v): %v", q.Values(), err)
+		}
+		if got, want := parsed.Values().Encode(), q.Values().Encode(); got != want {
+			t.Errorf("round trip = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, raw := range []string{"copyright=maybe", "languages=en,zz", "sort=random", "topic=+"} {
+		v, _ := url.ParseQuery(raw)
+		if _, err := ParseQuery(v); !IsInvalidQuery(err) {
+			t.Errorf("ParseQuery(%s) = %v, want invalid query", raw, err)
+		}
+	}
+	v, _ := url.ParseQuery("search=x&page=3")
+	if q, err := ParseQuery(v); err != nil || q.Search != "x" {
+		t.Fatalf("ParseQuery ignoring page = %+v, %v", q, err)
+	}
+}
+
+func TestCatalogCopyrightAndSearch(t *testing.T) {
+	pd, cr := false, true
+	books := testBooks()
+	books[0].Copyright = &pd
+	books[1].Copyright = &cr
+	c := NewCatalog(books...)
+	if got := collectIDs(t, c.ListBooks(Query{Copyright: &pd})); !slices.Equal(got, []int{1}) {
+		t.Fatalf("public domain ids = %v", got)
+	}
+	if got := collectIDs(t, c.ListBooks(Query{Search: "austen emma"})); !slices.Equal(got, []int{2}) {
+		t.Fatalf("search ids = %v", got)
+	}
+	if got := collectIDs(t, c.ListBooks(Query{Author: "austen", Title: "emma", SeparateSearch: true})); !slices.Equal(got, []int{2}) {
+		t.Fatalf("separate ids = %v", got)
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	b := Book{Title: "Emma", Authors: []Person{{Name: "Austen, Jane"}}, Languages: []string{"en"}, Formats: map[string]string{"text/html": "u"}}
+	tests := []struct {
+		q    Query
+		want bool
+	}{
+		{Query{}, true},
+		{Query{Author: "austen", Title: "emma"}, true},
+		{Query{Language: "fr, EN"}, true},
+		{Query{Language: "xx"}, false},
+		{Query{MIME: "text"}, true},
+		{Query{MIME: "bogus"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.q.Match(b); got != tt.want {
+			t.Errorf("%+v.Match = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+}
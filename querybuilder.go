@@ -0,0 +1,130 @@
+package gutendex
+
+import (
+	"errors"
+	"fmt"
+)
+
+// QueryBuilder assembles a Query step by step:
+//
+//	q, err := gutendex.NewQuery().
+//		Author("Verne").
+//		Languages("en", "fr").
+//		PublicDomainOnly().
+//		Sort(gutendex.SortPopular).
+//		Build()
+//
+// Unlike setting Query fields directly, Author and Title are kept as
+// separate filters unless CombineSearch is called, and contradictory calls
+// are reported by Validate and Build.
+type QueryBuilder struct {
+	q    Query
+	errs []error
+}
+
+// NewQuery returns an empty QueryBuilder.
+func NewQuery() *QueryBuilder {
+	return &QueryBuilder{q: Query{SeparateSearch: true}}
+}
+
+// Author filters by author name.
+func (b *QueryBuilder) Author(name string) *QueryBuilder {
+	b.set("author", &b.q.Author, name)
+	return b
+}
+
+// Title filters by title.
+func (b *QueryBuilder) Title(title string) *QueryBuilder {
+	b.set("title", &b.q.Title, title)
+	return b
+}
+
+// Search adds a free-text search over titles and author names.
+func (b *QueryBuilder) Search(terms string) *QueryBuilder {
+	b.set("search", &b.q.Search, terms)
+	return b
+}
+
+// Topic filters by subject or bookshelf.
+func (b *QueryBuilder) Topic(topic string) *QueryBuilder {
+	b.set("topic", &b.q.Topic, topic)
+	return b
+}
+
+// Languages adds language codes; books in any of them match.
+func (b *QueryBuilder) Languages(codes ...string) *QueryBuilder {
+	b.q.Languages = append(b.q.Languages, codes...)
+	return b
+}
+
+// MIME filters by format MIME type or type prefix, e.g. "text/html".
+func (b *QueryBuilder) MIME(prefix string) *QueryBuilder {
+	b.set("mime_type", &b.q.MIME, prefix)
+	return b
+}
+
+// Sort sets the result order.
+func (b *QueryBuilder) Sort(order SortOrder) *QueryBuilder {
+	if b.q.Sort != "" && b.q.Sort != order {
+		b.errs = append(b.errs, fmt.Errorf("conflicting sort orders %q and %q", b.q.Sort, order))
+	}
+	b.q.Sort = order
+	return b
+}
+
+// Copyright keeps only books whose copyright flag equals copyrighted.
+func (b *QueryBuilder) Copyright(copyrighted bool) *QueryBuilder {
+	if b.q.Copyright != nil && *b.q.Copyright != copyrighted {
+		b.errs = append(b.errs, errors.New("conflicting copyright filters"))
+	}
+	b.q.Copyright = &copyrighted
+	return b
+}
+
+// PublicDomainOnly keeps only books in the public domain in the USA.
+func (b *QueryBuilder) PublicDomainOnly() *QueryBuilder { return b.Copyright(false) }
+
+// CombineSearch controls whether Author and Title, when both are set, are
+// sent as one combined search instead of two separate filters.
+func (b *QueryBuilder) CombineSearch(combine bool) *QueryBuilder {
+	b.q.SeparateSearch = !combine
+	return b
+}
+
+// set assigns a single-valued filter, recording a conflict if it was
+// already set to something else.
+func (b *QueryBuilder) set(name string, field *string, value string) {
+	if *field != "" && *field != value {
+		b.errs = append(b.errs, fmt.Errorf("conflicting %s filters %q and %q", name, *field, value))
+	}
+	*field = value
+}
+
+// Query returns the query built so far, without validating it.
+func (b *QueryBuilder) Query() Query {
+	q := b.q
+	q.Languages = append([]string(nil), b.q.Languages...)
+	return q
+}
+
+// Validate reports conflicting builder calls together with the problems
+// found by Query.Validate.
+func (b *QueryBuilder) Validate() error {
+	problems := append([]error(nil), b.errs...)
+	var qerr *Error
+	if err := b.q.Validate(); errors.As(err, &qerr) {
+		problems = append(problems, qerr.Err)
+	}
+	if len(problems) > 0 {
+		return &Error{Op: "Query", Kind: ErrInvalidQuery, Err: errors.Join(problems...)}
+	}
+	return nil
+}
+
+// Build validates and returns the query.
+func (b *QueryBuilder) Build() (Query, error) {
+	if err := b.Validate(); err != nil {
+		return Query{}, err
+	}
+	return b.Query(), nil
+}
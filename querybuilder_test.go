@@ -0,0 +1,48 @@
+package gutendex
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	q, err := NewQuery().Author("x").Title("y").Languages("en", "fr").Sort(SortPopular).PublicDomainOnly().Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	want := url.Values{
+		"author": {"x"}, "title": {"y"}, "languages": {"en,fr"},
+		"sort": {"popular"}, "copyright": {"false"},
+	}
+	if got := q.Values(); got.Encode() != want.Encode() {
+		t.Fatalf("Values() = %v, want %v", got, want)
+	}
+
+	q, err = NewQuery().Author("x").Title("y").CombineSearch(true).Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if got := q.Values().Encode(); got != "search=x+y" {
+		t.Fatalf("combined Values() = %s", got)
+	}
+}
+
+func TestQueryBuilderValidate(t *testing.T) {
+	b := NewQuery().Author("a").Author("b").PublicDomainOnly().Copyright(true).Languages("en", "xx").MIME("Text/HTML")
+	err := b.Validate()
+	if !IsInvalidQuery(err) {
+		t.Fatalf("Validate() = %v", err)
+	}
+	for _, want := range []string{"conflicting author", "conflicting copyright", `unknown language code "xx"`, "malformed MIME"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if _, err := b.Build(); err == nil {
+		t.Fatal("Build accepted an invalid query")
+	}
+	if err := NewQuery().Author("a").Author("a").Validate(); err != nil {
+		t.Fatalf("repeating a value is not a conflict: %v", err)
+	}
+}
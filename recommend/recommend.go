@@ -0,0 +1,331 @@
+// Package recommend finds books similar to a given book, for "readers also
+// liked" lists, without an external recommendation service.
+//
+// Similarity combines shared authors, subjects, bookshelves, languages and
+// era. Subjects are compared heading by heading: sharing "England -- Social
+// life and customs" counts for more than sharing only "England", while a
+// shared form subdivision such as "Fiction" adds little. Results are fully
+// determined by the books and weights, so they are stable across runs.
+package recommend
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+	"github.com/alex-rs/go-gutendex/subject"
+)
+
+// Weights sets the contribution of each signal to a similarity score.
+type Weights struct {
+	Authors     float64
+	Subjects    float64
+	Bookshelves float64
+	Languages   float64
+	Era         float64
+}
+
+// DefaultWeights favours shared authors and subjects.
+var DefaultWeights = Weights{Authors: 3, Subjects: 3, Bookshelves: 1.5, Languages: 1, Era: 1}
+
+// EraSpan is the difference in author birth years, in years, at which the
+// era signal reaches zero.
+const EraSpan = 100
+
+// Match is a recommended book and its similarity score between 0 and 1.
+type Match struct {
+	Book  gutendex.Book
+	Score float64
+}
+
+// Similarity scores the similarity of a and b between 0 and 1 under w.
+func Similarity(a, b gutendex.Book, w Weights) float64 {
+	return score(newFeatures(a), newFeatures(b), w)
+}
+
+// Recommender finds similar books in a fixed set of candidates. It is not
+// safe for concurrent modification.
+type Recommender struct {
+	// Weights scores candidates. New sets it to DefaultWeights.
+	Weights Weights
+
+	books []gutendex.Book
+	feats []features
+	ids   map[int]bool
+	// index maps author, subject and bookshelf keys to book positions, so
+	// that only books sharing one of them are scored.
+	index map[string][]int
+}
+
+// New returns a recommender over books.
+func New(books ...gutendex.Book) *Recommender {
+	r := &Recommender{Weights: DefaultWeights, ids: make(map[int]bool), index: make(map[string][]int)}
+	r.Add(books...)
+	return r
+}
+
+// Build returns a recommender over every book produced by it. Set the
+// iterator's context with Iter.Context to be able to cancel a build.
+func Build(it *gutendex.Iter[gutendex.Book]) (*Recommender, error) {
+	r := New()
+	for it.Next() {
+		r.Add(it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Crawl returns a recommender over books of src related to b: books by its
+// authors and books on its main subjects and bookshelves. At most limit
+// books other than b are kept, shared evenly among these queries, which
+// bounds the number of API requests.
+func Crawl(ctx context.Context, src gutendex.BookSource, b gutendex.Book, limit int) (*Recommender, error) {
+	var queries []gutendex.Query
+	seen := make(map[string]bool)
+	addQuery := func(q gutendex.Query) {
+		if key := q.Values().Encode(); !seen[key] {
+			seen[key] = true
+			queries = append(queries, q)
+		}
+	}
+	for _, p := range b.Authors {
+		if f := p.FamilyName(); f != "" {
+			addQuery(gutendex.Query{Author: f})
+		}
+	}
+	for _, s := range b.Subjects {
+		if m := subject.Parse(s).Main(); m != "" {
+			addQuery(gutendex.Query{Topic: m})
+		}
+	}
+	for _, s := range b.Bookshelves {
+		addQuery(gutendex.Query{Topic: gutendex.ShelfLabel(s)})
+	}
+	r := New()
+	if len(queries) == 0 || limit <= 0 {
+		return r, nil
+	}
+	per := (limit + len(queries) - 1) / len(queries)
+	fetched := 0
+	for _, q := range queries {
+		n := min(per, limit-fetched)
+		if n <= 0 {
+			break
+		}
+		// Skip b and books already fetched, so they do not use up the
+		// budget.
+		books, err := src.ListBooks(q).Filter(func(x gutendex.Book) bool {
+			return x.ID != b.ID && !r.ids[x.ID]
+		}).Take(n).Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		fetched += len(books)
+		r.Add(books...)
+	}
+	return r, nil
+}
+
+// Add adds candidate books. Books whose ID was already added are ignored.
+func (r *Recommender) Add(books ...gutendex.Book) {
+	for _, b := range books {
+		if r.ids[b.ID] {
+			continue
+		}
+		r.ids[b.ID] = true
+		i := len(r.books)
+		f := newFeatures(b)
+		r.books = append(r.books, b)
+		r.feats = append(r.feats, f)
+		for _, k := range f.keys() {
+			r.index[k] = append(r.index[k], i)
+		}
+	}
+}
+
+// Len returns the number of candidate books.
+func (r *Recommender) Len() int { return len(r.books) }
+
+// Similar returns up to k candidates most similar to b, best first, ties
+// broken by download count and then ID. Only candidates sharing an author,
+// subject or bookshelf with b are considered, and b itself is excluded.
+func (r *Recommender) Similar(b gutendex.Book, k int) []Match {
+	f := newFeatures(b)
+	scored := make(map[int]bool)
+	var out []Match
+	for _, key := range f.keys() {
+		for _, i := range r.index[key] {
+			if scored[i] || r.books[i].ID == b.ID {
+				continue
+			}
+			scored[i] = true
+			if s := score(f, r.feats[i], r.Weights); s > 0 {
+				out = append(out, Match{Book: r.books[i], Score: s})
+			}
+		}
+	}
+	slices.SortFunc(out, func(x, y Match) int {
+		if c := cmp.Compare(y.Score, x.Score); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(y.Book.DownloadCount, x.Book.DownloadCount); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.Book.ID, y.Book.ID)
+	})
+	if k >= 0 && len(out) > k {
+		out = out[:k]
+	}
+	return out
+}
+
+// features holds the parts of a book that similarity is computed from.
+type features struct {
+	authors   []gutendex.Person
+	subjects  map[string]float64
+	shelves   map[string]float64
+	languages map[string]float64
+	// born is the mean birth year of the authors; ok is false if none is
+	// known.
+	born float64
+	ok   bool
+}
+
+func newFeatures(b gutendex.Book) features {
+	f := features{
+		authors:   b.Authors,
+		subjects:  make(map[string]float64),
+		shelves:   make(map[string]float64),
+		languages: make(map[string]float64),
+	}
+	for _, s := range b.Subjects {
+		for key, w := range headingKeys(subject.Parse(s)) {
+			f.subjects[key] = max(f.subjects[key], w)
+		}
+	}
+	for _, s := range b.Bookshelves {
+		f.shelves[strings.ToLower(gutendex.ShelfLabel(s))] = 1
+	}
+	for _, l := range b.Languages {
+		f.languages[strings.ToLower(l)] = 1
+	}
+	var sum, n float64
+	for _, p := range b.Authors {
+		switch {
+		case p.BirthYear != nil:
+			sum += float64(*p.BirthYear)
+		case p.DeathYear != nil:
+			// Assume a working life ending around 60 years after birth.
+			sum += float64(*p.DeathYear - 60)
+		default:
+			continue
+		}
+		n++
+	}
+	if n > 0 {
+		f.born, f.ok = sum/n, true
+	}
+	return f
+}
+
+// headingKeys returns the prefixes of h with their weights. The main
+// heading weighs 1 and each subdivision halves the weight, so matching
+// deeper headings adds progressively more specific evidence; form
+// subdivisions, shared by unrelated books, are halved again.
+func headingKeys(h subject.Heading) map[string]float64 {
+	keys := make(map[string]float64, len(h.Parts))
+	depth := 1.0
+	var prefix strings.Builder
+	for i, p := range h.Parts {
+		if i > 0 {
+			prefix.WriteString(subject.Separator)
+			depth /= 2
+		}
+		w := depth
+		if i > 0 && p.Kind == subject.Form {
+			w /= 2
+		}
+		prefix.WriteString(strings.ToLower(p.Term))
+		keys[prefix.String()] = w
+	}
+	return keys
+}
+
+// keys returns the index keys of f: author family names, main subject
+// headings and bookshelves.
+func (f features) keys() []string {
+	var out []string
+	for _, p := range f.authors {
+		if n := p.FamilyName(); n != "" {
+			out = append(out, "a:"+strings.ToLower(n))
+		}
+	}
+	for k, w := range f.subjects {
+		if w == 1 {
+			out = append(out, "s:"+k)
+		}
+	}
+	for k := range f.shelves {
+		out = append(out, "b:"+k)
+	}
+	// Sorted so that candidates are visited, and ties resolved, in the
+	// same order on every run.
+	slices.Sort(out)
+	return out
+}
+
+func score(a, b features, w Weights) float64 {
+	total := w.Authors + w.Subjects + w.Bookshelves + w.Languages + w.Era
+	if total <= 0 {
+		return 0
+	}
+	s := w.Authors*authorOverlap(a.authors, b.authors) +
+		w.Subjects*jaccard(a.subjects, b.subjects) +
+		w.Bookshelves*jaccard(a.shelves, b.shelves) +
+		w.Languages*jaccard(a.languages, b.languages)
+	if a.ok && b.ok {
+		s += w.Era * max(0, 1-math.Abs(a.born-b.born)/EraSpan)
+	}
+	return s / total
+}
+
+// jaccard returns the weighted Jaccard similarity of a and b: the sum of
+// the smaller weights of shared keys over the sum of the larger weights of
+// all keys.
+func jaccard(a, b map[string]float64) float64 {
+	var inter, union float64
+	for k, wa := range a {
+		wb := b[k]
+		inter += min(wa, wb)
+		union += max(wa, wb)
+	}
+	for k, wb := range b {
+		if _, ok := a[k]; !ok {
+			union += wb
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return inter / union
+}
+
+// authorOverlap returns the share of distinct authors of a and b that
+// wrote both, matching names with gutendex.SamePerson.
+func authorOverlap(a, b []gutendex.Person) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for _, p := range a {
+		if slices.ContainsFunc(b, func(q gutendex.Person) bool { return gutendex.SamePerson(p, q) }) {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,136 @@
+package recommend
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+	"github.com/alex-rs/go-gutendex/subject"
+)
+
+func year(n int) *int { return &n }
+
+var (
+	austen  = gutendex.Person{Name: "Austen, Jane", BirthYear: year(1775), DeathYear: year(1817)}
+	bronte  = gutendex.Person{Name: "Brontë, Charlotte", BirthYear: year(1816), DeathYear: year(1855)}
+	wells   = gutendex.Person{Name: "Wells, H. G. (Herbert George)", BirthYear: year(1866), DeathYear: year(1946)}
+	verne   = gutendex.Person{Name: "Verne, Jules", BirthYear: year(1828), DeathYear: year(1905)}
+	shelves = []string{"Browsing: Literature", "Best Books Ever Listings"}
+)
+
+func testBooks() []gutendex.Book {
+	return []gutendex.Book{
+		{ID: 1342, Title: "Pride and Prejudice", Authors: []gutendex.Person{austen}, Languages: []string{"en"},
+			Subjects:    []string{"England -- Social life and customs -- 19th century -- Fiction", "Courtship -- Fiction", "Sisters -- Fiction"},
+			Bookshelves: shelves, DownloadCount: 900},
+		{ID: 158, Title: "Emma", Authors: []gutendex.Person{austen}, Languages: []string{"en"},
+			Subjects:    []string{"England -- Social life and customs -- 19th century -- Fiction", "Young women -- Fiction"},
+			Bookshelves: shelves, DownloadCount: 300},
+		{ID: 1260, Title: "Jane Eyre", Authors: []gutendex.Person{bronte}, Languages: []string{"en"},
+			Subjects:    []string{"England -- Fiction", "Governesses -- Fiction", "Young women -- Fiction"},
+			Bookshelves: shelves, DownloadCount: 500},
+		{ID: 35, Title: "The Time Machine", Authors: []gutendex.Person{wells}, Languages: []string{"en"},
+			Subjects: []string{"Time travel -- Fiction", "Science fiction"}, DownloadCount: 400},
+		{ID: 164, Title: "Twenty Thousand Leagues under the Sea", Authors: []gutendex.Person{verne}, Languages: []string{"en"},
+			Subjects: []string{"Submarines (Ships) -- Fiction", "Science fiction"}, DownloadCount: 350},
+		{ID: 5000, Title: "Le Tour du monde en quatre-vingts jours", Authors: []gutendex.Person{verne}, Languages: []string{"fr"},
+			Subjects: []string{"Voyages around the world -- Fiction"}, DownloadCount: 50},
+	}
+}
+
+func ids(ms []Match) []int {
+	out := make([]int, len(ms))
+	for i, m := range ms {
+		out[i] = m.Book.ID
+	}
+	return out
+}
+
+func TestSimilar(t *testing.T) {
+	books := testBooks()
+	r := New(books...)
+	got := r.Similar(books[0], 3)
+	if want := []int{158, 1260}; !slices.Equal(ids(got), want) {
+		t.Fatalf("Similar(Pride and Prejudice) = %v, want %v", ids(got), want)
+	}
+	if got[0].Score <= got[1].Score || got[0].Score > 1 {
+		t.Errorf("scores = %v, %v", got[0].Score, got[1].Score)
+	}
+
+	got = r.Similar(books[3], 5)
+	if want := []int{164}; !slices.Equal(ids(got), want) {
+		t.Fatalf("Similar(The Time Machine) = %v, want %v", ids(got), want)
+	}
+	// Same author outweighs a shared genre.
+	got = r.Similar(books[4], 5)
+	if want := []int{5000, 35}; !slices.Equal(ids(got), want) {
+		t.Fatalf("Similar(Twenty Thousand Leagues) = %v, want %v", ids(got), want)
+	}
+}
+
+func TestSimilarDeterministic(t *testing.T) {
+	books := testBooks()
+	want := ids(New(books...).Similar(books[2], -1))
+	for range 20 {
+		slices.Reverse(books)
+		if got := ids(New(books...).Similar(testBooks()[2], -1)); !slices.Equal(got, want) {
+			t.Fatalf("Similar = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSubdivisionWeighting(t *testing.T) {
+	book := func(s string) gutendex.Book { return gutendex.Book{Subjects: []string{s}} }
+	base := book("England -- Social life and customs -- 19th century")
+	w := Weights{Subjects: 1}
+	same := Similarity(base, book("England -- Social life and customs -- 19th century"), w)
+	deep := Similarity(base, book("England -- Social life and customs -- 18th century"), w)
+	shallow := Similarity(base, book("England -- Politics and government"), w)
+	if same != 1 || !(deep > shallow && shallow > 0) {
+		t.Fatalf("same = %v, deep = %v, shallow = %v", same, deep, shallow)
+	}
+	// A shared form subdivision counts for less than a topical one.
+	form := headingKeys(subject.Parse("England -- Fiction"))["england -- fiction"]
+	topical := headingKeys(subject.Parse("England -- History"))["england -- history"]
+	if form >= topical {
+		t.Fatalf("form weight %v >= topical weight %v", form, topical)
+	}
+}
+
+func TestEra(t *testing.T) {
+	w := Weights{Era: 1}
+	a := gutendex.Book{Authors: []gutendex.Person{austen}}
+	if s := Similarity(a, gutendex.Book{Authors: []gutendex.Person{bronte}}, w); s < 0.5 || s >= 1 {
+		t.Errorf("Austen/Brontë era = %v", s)
+	}
+	if s := Similarity(a, gutendex.Book{Authors: []gutendex.Person{{Name: "Homer", DeathYear: year(-700)}}}, w); s != 0 {
+		t.Errorf("Austen/Homer era = %v", s)
+	}
+	if s := Similarity(a, gutendex.Book{}, w); s != 0 {
+		t.Errorf("unknown era = %v", s)
+	}
+}
+
+func TestCrawl(t *testing.T) {
+	books := testBooks()
+	src := gutendex.NewCatalog(books...)
+	r, err := Crawl(context.Background(), src, books[0], 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() == 0 || r.Len() > 10 {
+		t.Fatalf("crawled %d books", r.Len())
+	}
+	got := r.Similar(books[0], 2)
+	if want := []int{158, 1260}; !slices.Equal(ids(got), want) {
+		t.Fatalf("Similar after crawl = %v, want %v", ids(got), want)
+	}
+	r, err = Crawl(context.Background(), src, books[0], 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != 1 {
+		t.Fatalf("crawl limit 1 fetched %d books", r.Len())
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"cmp"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// filter holds the query parameters understood by /books. Those shared
+// with the client are parsed by gutendex.ParseQuery; the rest are ones a
+// gutendex.Query cannot express. Like upstream, the server never rejects a
+// query: unknown values match nothing or, for sort, are ignored.
+type filter struct {
+	query     gutendex.Query
+	ids       map[int]bool
+	yearStart *int
+	yearEnd   *int
+	// copyright accepts a comma-separated list of "true", "false" and
+	// "null", where a Query holds a single flag.
+	copyright map[string]bool
+}
+
+func parseFilter(v url.Values) filter {
+	var f filter
+	if s := v.Get("ids"); s != "" {
+		f.ids = make(map[int]bool)
+		for _, part := range strings.Split(s, ",") {
+			if id, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+				f.ids[id] = true
+			}
+		}
+	}
+	f.yearStart = intParam(v, "author_year_start")
+	f.yearEnd = intParam(v, "author_year_end")
+	if s := v.Get("copyright"); s != "" {
+		f.copyright = make(map[string]bool)
+		for _, part := range strings.Split(s, ",") {
+			f.copyright[strings.TrimSpace(part)] = true
+		}
+	}
+	rest := maps.Clone(v)
+	delete(rest, "copyright")
+	// With copyright removed, ParseQuery fails only validation, which
+	// upstream does not apply; the parsed query is returned regardless.
+	f.query, _ = gutendex.ParseQuery(rest)
+	return f
+}
+
+func intParam(v url.Values, key string) *int {
+	n, err := strconv.Atoi(v.Get(key))
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func (f filter) match(b gutendex.Book) bool {
+	if !f.query.Match(b) {
+		return false
+	}
+	if f.ids != nil && !f.ids[b.ID] {
+		return false
+	}
+	if f.yearStart != nil && !anyAuthor(b, func(p gutendex.Person) bool {
+		return atLeast(p.BirthYear, *f.yearStart) || atLeast(p.DeathYear, *f.yearStart)
+	}) {
+		return false
+	}
+	if f.yearEnd != nil && !anyAuthor(b, func(p gutendex.Person) bool {
+		return atMost(p.BirthYear, *f.yearEnd) || atMost(p.DeathYear, *f.yearEnd)
+	}) {
+		return false
+	}
+	if f.copyright != nil && !f.copyright[copyrightKey(b.Copyright)] {
+		return false
+	}
+	return true
+}
+
+// sortBooks applies the upstream sort parameter to books, which arrive
+// ordered by popularity. Unknown orders keep that order, as upstream.
+func sortBooks(books []gutendex.Book, order gutendex.SortOrder) {
+	switch order {
+	case gutendex.SortAscending:
+		slices.SortFunc(books, func(a, b gutendex.Book) int { return cmp.Compare(a.ID, b.ID) })
+	case gutendex.SortDescending:
+		slices.SortFunc(books, func(a, b gutendex.Book) int { return cmp.Compare(b.ID, a.ID) })
+	}
+}
+
+func anyAuthor(b gutendex.Book, fn func(gutendex.Person) bool) bool {
+	return slices.ContainsFunc(b.Authors, fn)
+}
+
+func copyrightKey(c *bool) string {
+	if c == nil {
+		return "null"
+	}
+	return strconv.FormatBool(*c)
+}
+
+func atLeast(year *int, n int) bool { return year != nil && *year >= n }
+
+func atMost(year *int, n int) bool { return year != nil && *year <= n }
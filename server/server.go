@@ -0,0 +1,198 @@
+// Package server serves a Gutendex-compatible HTTP API from a local catalog.
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// DefaultPageSize is the number of results per page used by Gutendex.
+const DefaultPageSize = 32
+
+// Server is an http.Handler answering /books and /books/{id} the way the
+// upstream Gutendex service does.
+type Server struct {
+	catalog  atomic.Pointer[gutendex.Catalog]
+	pageSize int
+	baseURL  *url.URL
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithPageSize overrides the number of results per page.
+func WithPageSize(n int) Option {
+	return func(s *Server) {
+		if n > 0 {
+			s.pageSize = n
+		}
+	}
+}
+
+// WithBaseURL sets the public URL used to build next and previous links,
+// for deployments behind a reverse proxy. By default links are derived from
+// the incoming request.
+func WithBaseURL(u *url.URL) Option {
+	return func(s *Server) { s.baseURL = u }
+}
+
+// New constructs a Server answering from c.
+func New(c *gutendex.Catalog, opts ...Option) *Server {
+	s := &Server{pageSize: DefaultPageSize}
+	s.catalog.Store(c)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Reload atomically replaces the served catalog. Requests in flight finish
+// against the snapshot they started with.
+func (s *Server) Reload(c *gutendex.Catalog) { s.catalog.Store(c) }
+
+// Catalog returns the catalog currently being served.
+func (s *Server) Catalog() *gutendex.Catalog { return s.catalog.Load() }
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeJSON(w, http.StatusMethodNotAllowed, detail(`Method "`+r.Method+`" not allowed.`))
+		return
+	}
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "books":
+		s.listBooks(w, r)
+	case strings.HasPrefix(path, "books/"):
+		id, err := strconv.Atoi(strings.TrimPrefix(path, "books/"))
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, detail("Not found."))
+			return
+		}
+		s.getBook(w, r, id)
+	default:
+		writeJSON(w, http.StatusNotFound, detail("Not found."))
+	}
+}
+
+func (s *Server) getBook(w http.ResponseWriter, r *http.Request, id int) {
+	b, err := s.Catalog().GetBook(r.Context(), id)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, detail("Not found."))
+		return
+	}
+	writeJSON(w, http.StatusOK, normalize(*b))
+}
+
+func (s *Server) listBooks(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	page := 1
+	if p := params.Get("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			writeJSON(w, http.StatusNotFound, detail("Invalid page."))
+			return
+		}
+		page = n
+	}
+
+	f := parseFilter(params)
+	books := s.Catalog().Books(f.match)
+	sortBooks(books, f.query.Sort)
+
+	start := (page - 1) * s.pageSize
+	if start > 0 && start >= len(books) {
+		writeJSON(w, http.StatusNotFound, detail("Invalid page."))
+		return
+	}
+	end := min(start+s.pageSize, len(books))
+
+	resp := gutendex.Page[gutendex.Book]{
+		Count:   len(books),
+		Results: make([]gutendex.Book, 0, end-start),
+	}
+	for _, b := range books[start:end] {
+		resp.Results = append(resp.Results, normalize(b))
+	}
+	if end < len(books) {
+		next := s.pageURL(r, page+1)
+		resp.Next = &next
+	}
+	if page > 1 {
+		prev := s.pageURL(r, page-1)
+		resp.Previous = &prev
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// pageURL mirrors Django REST framework pagination links: the request URL
+// with the page parameter replaced, or removed for the first page.
+func (s *Server) pageURL(r *http.Request, page int) string {
+	u := *r.URL
+	if s.baseURL != nil {
+		u.Scheme = s.baseURL.Scheme
+		u.Host = s.baseURL.Host
+		u.Path = strings.TrimRight(s.baseURL.Path, "/") + r.URL.Path
+	} else {
+		u.Scheme = "http"
+		if r.TLS != nil {
+			u.Scheme = "https"
+		}
+		if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+			u.Scheme = proto
+		}
+		u.Host = r.Host
+	}
+	q := r.URL.Query()
+	if page == 1 {
+		q.Del("page")
+	} else {
+		q.Set("page", strconv.Itoa(page))
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// normalize replaces nil collections so they encode as [] and {} rather
+// than null, matching upstream responses.
+func normalize(b gutendex.Book) gutendex.Book {
+	for _, p := range []*[]gutendex.Person{&b.Authors, &b.Translators} {
+		if *p == nil {
+			*p = []gutendex.Person{}
+		}
+	}
+	for _, p := range []*[]string{&b.Subjects, &b.Bookshelves, &b.Languages} {
+		if *p == nil {
+			*p = []string{}
+		}
+	}
+	if b.Formats == nil {
+		b.Formats = map[string]string{}
+	}
+	return b
+}
+
+func detail(msg string) map[string]string { return map[string]string{"detail": msg} }
+
+// writeJSON encodes v compactly without HTML escaping, as Django REST
+// framework does.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
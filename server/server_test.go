@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+func intPtr(n int) *int { return &n }
+
+func testCatalog(n int) *gutendex.Catalog {
+	c := gutendex.NewCatalog()
+	for i := 1; i <= n; i++ {
+		_ = c.PutBooks(context.Background(), gutendex.Book{
+			ID:            i,
+			Title:         fmt.Sprintf("Book %d", i),
+			Authors:       []gutendex.Person{{Name: "Author, Some", BirthYear: intPtr(1800 + i), DeathYear: intPtr(1850 + i)}},
+			Languages:     []string{"en"},
+			DownloadCount: i,
+		})
+	}
+	return c
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("get %s: %v", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestGetBookJSON(t *testing.T) {
+	c := gutendex.NewCatalog(gutendex.Book{ID: 7, Title: "A & B", MediaType: "Text"})
+	srv := httptest.NewServer(New(c))
+	defer srv.Close()
+
+	status, body := get(t, srv.URL+"/books/7/")
+	want := `{"id":7,"title":"A & B","authors":[],"translators":[],"subjects":[],"bookshelves":[],"languages":[],"copyright":null,"media_type":"Text","formats":{},"download_count":0}`
+	if status != http.StatusOK || body != want {
+		t.Fatalf("got %d %s", status, body)
+	}
+
+	status, body = get(t, srv.URL+"/books/8")
+	if status != http.StatusNotFound || body != `{"detail":"Not found."}` {
+		t.Fatalf("got %d %s", status, body)
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	srv := httptest.NewServer(New(testCatalog(5), WithPageSize(2)))
+	defer srv.Close()
+
+	status, body := get(t, srv.URL+"/books/?sort=ascending&page=2")
+	if status != http.StatusOK {
+		t.Fatalf("status %d", status)
+	}
+	for _, want := range []string{
+		`{"count":5,"next":"` + srv.URL + `/books/?page=3&sort=ascending","previous":"` + srv.URL + `/books/?sort=ascending","results":[{"id":3,`,
+		`{"id":4,`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("body %s missing %s", body, want)
+		}
+	}
+
+	if status, _ := get(t, srv.URL+"/books?page=4"); status != http.StatusNotFound {
+		t.Fatalf("expected invalid page, got %d", status)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	srv := httptest.NewServer(New(testCatalog(5)))
+	defer srv.Close()
+
+	tests := []struct {
+		query string
+		count int
+	}{
+		{"", 5},
+		{"ids=1,3,9", 2},
+		{"author_year_start=1854", 2},
+		{"author_year_end=1802", 2},
+		{"search=book%203", 1},
+		{"languages=fr", 0},
+		{"copyright=null", 5},
+		{"copyright=false", 0},
+		{"copyright=true,null", 5},
+		{"author=some&title=book%202", 1},
+		{"topic=fiction", 0},
+		{"sort=descending&mime_type=text", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, body := get(t, srv.URL+"/books?"+tt.query)
+			if want := fmt.Sprintf(`{"count":%d,`, tt.count); !strings.HasPrefix(body, want) {
+				t.Fatalf("body %s, want prefix %s", body, want)
+			}
+		})
+	}
+}
+
+func TestListUnknownValues(t *testing.T) {
+	srv := httptest.NewServer(New(testCatalog(3)))
+	defer srv.Close()
+	// Upstream answers unknown values with an empty or default-ordered
+	// result, never with an error.
+	tests := []struct {
+		query, prefix string
+	}{
+		{"languages=xx", `{"count":0,"next":null,"previous":null,"results":[]}`},
+		{"languages=xx,en", `{"count":3,`},
+		{"mime_type=bogus", `{"count":0,`},
+		{"sort=bogus", `{"count":3,"next":null,"previous":null,"results":[{"id":3,`},
+		{"copyright=maybe", `{"count":0,`},
+	}
+	for _, tt := range tests {
+		status, body := get(t, srv.URL+"/books?"+tt.query)
+		if status != http.StatusOK || !strings.HasPrefix(body, tt.prefix) {
+			t.Errorf("%s: status %d, body %s; want 200, prefix %s", tt.query, status, body, tt.prefix)
+		}
+	}
+}
+
+func TestClientAgainstServer(t *testing.T) {
+	s := New(testCatalog(40))
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	client := gutendex.NewClient(gutendex.WithBaseURL(srv.URL))
+	it := client.ListBooks(gutendex.Query{Language: "en"})
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if err := it.Err(); err != nil || n != 40 {
+		t.Fatalf("iterated %d books, err %v", n, err)
+	}
+
+	s.Reload(testCatalog(1))
+	if _, err := client.GetBook(context.Background(), 2); !gutendex.IsNotFound(err) {
+		t.Fatalf("expected reload to drop book 2, got %v", err)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	srv := httptest.NewServer(New(testCatalog(1)))
+	defer srv.Close()
+	resp, err := http.Post(srv.URL+"/books", "application/json", nil)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+}
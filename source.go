@@ -0,0 +1,289 @@
+package gutendex
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BookSource provides read access to book records. It is implemented by
+// Client for the remote API and by Catalog for a local index, and can be
+// composed with the decorators in this file.
+type BookSource interface {
+	GetBook(ctx context.Context, id int) (*Book, error)
+	GetBooks(ctx context.Context, ids []int) ([]Book, error)
+	ListBooks(q Query) *Iter[Book]
+}
+
+// BookStore is a BookSource that can also persist books.
+type BookStore interface {
+	BookSource
+	PutBooks(ctx context.Context, books ...Book) error
+}
+
+// NewFallbackSource returns a source that consults primary first and falls
+// back to secondary when primary fails or has nothing to offer, including
+// when it reports a book as not found. A typical use is a local catalog in
+// front of the remote API. Once the caller's context is done, the error
+// from primary is returned without consulting secondary.
+func NewFallbackSource(primary, secondary BookSource) BookSource {
+	return &fallbackSource{primary: primary, secondary: secondary}
+}
+
+type fallbackSource struct {
+	primary, secondary BookSource
+}
+
+func (s *fallbackSource) GetBook(ctx context.Context, id int) (*Book, error) {
+	b, err := s.primary.GetBook(ctx, id)
+	if err == nil {
+		return b, nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+	return s.secondary.GetBook(ctx, id)
+}
+
+func (s *fallbackSource) GetBooks(ctx context.Context, ids []int) ([]Book, error) {
+	found, err := s.primary.GetBooks(ctx, ids)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return s.secondary.GetBooks(ctx, ids)
+	}
+	missing := missingIDs(found, ids)
+	if len(missing) == 0 {
+		return found, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	rest, err := s.secondary.GetBooks(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	return orderByIDs(append(found, rest...), ids), nil
+}
+
+// ListBooks streams results from primary, switching to secondary only when
+// primary yields no results at all.
+func (s *fallbackSource) ListBooks(q Query) *Iter[Book] {
+	var cur *Iter[Book]
+	return newPullIter(func(ctx context.Context) ([]Book, bool, error) {
+		if cur == nil {
+			cur = s.primary.ListBooks(q)
+			cur.ctx = ctx
+			if !cur.Next() {
+				if err := ctx.Err(); err != nil {
+					return nil, false, err
+				}
+				cur = s.secondary.ListBooks(q)
+				cur.ctx = ctx
+				if !cur.Next() {
+					return nil, false, cur.Err()
+				}
+			}
+			return []Book{cur.Value()}, true, nil
+		}
+		cur.ctx = ctx
+		if !cur.Next() {
+			return nil, false, cur.Err()
+		}
+		return []Book{cur.Value()}, true, nil
+	})
+}
+
+// NewCachingSource returns a source that memoizes books fetched from src for
+// ttl. Books streamed through ListBooks are cached as they are seen. A
+// non-positive ttl caches entries indefinitely. Cached books are copied on
+// the way in and out, so callers may modify the books they receive.
+func NewCachingSource(src BookSource, ttl time.Duration) BookSource {
+	return &cachingSource{src: src, ttl: ttl, entries: make(map[int]cacheEntry)}
+}
+
+type cacheEntry struct {
+	book    Book
+	expires time.Time
+}
+
+type cachingSource struct {
+	src BookSource
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[int]cacheEntry
+}
+
+func (s *cachingSource) lookup(id int) (Book, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return Book{}, false
+	}
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		delete(s.entries, id)
+		return Book{}, false
+	}
+	return e.book.clone(), true
+}
+
+func (s *cachingSource) store(books ...Book) {
+	var expires time.Time
+	if s.ttl > 0 {
+		expires = time.Now().Add(s.ttl)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range books {
+		s.entries[b.ID] = cacheEntry{book: b.clone(), expires: expires}
+	}
+}
+
+func (s *cachingSource) GetBook(ctx context.Context, id int) (*Book, error) {
+	if b, ok := s.lookup(id); ok {
+		return &b, nil
+	}
+	b, err := s.src.GetBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.store(*b)
+	return b, nil
+}
+
+func (s *cachingSource) GetBooks(ctx context.Context, ids []int) ([]Book, error) {
+	var found []Book
+	var missing []int
+	for _, id := range ids {
+		if b, ok := s.lookup(id); ok {
+			found = append(found, b)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		rest, err := s.src.GetBooks(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		s.store(rest...)
+		found = append(found, rest...)
+	}
+	return orderByIDs(found, ids), nil
+}
+
+func (s *cachingSource) ListBooks(q Query) *Iter[Book] {
+	return tee(s.src.ListBooks(q), func(b Book) error {
+		s.store(b)
+		return nil
+	})
+}
+
+// NewReadThroughSource returns a source that serves books from store and, on
+// a miss, fetches them from remote and writes them into store. Listings are
+// always answered by remote and every streamed book is written to store.
+func NewReadThroughSource(remote BookSource, store BookStore) BookSource {
+	return &readThroughSource{remote: remote, store: store}
+}
+
+type readThroughSource struct {
+	remote BookSource
+	store  BookStore
+}
+
+func (s *readThroughSource) GetBook(ctx context.Context, id int) (*Book, error) {
+	b, err := s.store.GetBook(ctx, id)
+	if err == nil {
+		return b, nil
+	}
+	if !IsNotFound(err) {
+		return nil, err
+	}
+	b, err = s.remote.GetBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.PutBooks(ctx, *b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *readThroughSource) GetBooks(ctx context.Context, ids []int) ([]Book, error) {
+	found, err := s.store.GetBooks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	missing := missingIDs(found, ids)
+	if len(missing) == 0 {
+		return found, nil
+	}
+	rest, err := s.remote.GetBooks(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		if err := s.store.PutBooks(ctx, rest...); err != nil {
+			return nil, err
+		}
+	}
+	return orderByIDs(append(found, rest...), ids), nil
+}
+
+func (s *readThroughSource) ListBooks(q Query) *Iter[Book] {
+	return tee(s.remote.ListBooks(q), func(b Book) error {
+		return s.store.PutBooks(context.Background(), b)
+	})
+}
+
+// tee wraps src so that fn observes every book before it is yielded. An error
+// from fn stops the iteration.
+func tee(src *Iter[Book], fn func(Book) error) *Iter[Book] {
+	return newPullIter(func(ctx context.Context) ([]Book, bool, error) {
+		src.ctx = ctx
+		if !src.Next() {
+			return nil, false, src.Err()
+		}
+		b := src.Value()
+		if err := fn(b); err != nil {
+			return nil, false, err
+		}
+		return []Book{b}, true, nil
+	})
+}
+
+// orderByIDs returns the books whose IDs appear in ids, in that order and
+// without duplicates.
+func orderByIDs(books []Book, ids []int) []Book {
+	byID := make(map[int]Book, len(books))
+	for _, b := range books {
+		byID[b.ID] = b
+	}
+	out := make([]Book, 0, len(books))
+	for _, id := range ids {
+		if b, ok := byID[id]; ok {
+			out = append(out, b)
+			delete(byID, id)
+		}
+	}
+	return out
+}
+
+// missingIDs returns the entries of ids that have no matching book.
+func missingIDs(books []Book, ids []int) []int {
+	have := make(map[int]bool, len(books))
+	for _, b := range books {
+		have[b.ID] = true
+	}
+	var missing []int
+	for _, id := range ids {
+		if !have[id] {
+			missing = append(missing, id)
+			have[id] = true
+		}
+	}
+	return missing
+}
@@ -0,0 +1,196 @@
+package gutendex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// countingSource records how often each method reaches the wrapped source.
+type countingSource struct {
+	BookSource
+	gets, lists int
+}
+
+func (s *countingSource) GetBook(ctx context.Context, id int) (*Book, error) {
+	s.gets++
+	return s.BookSource.GetBook(ctx, id)
+}
+
+func (s *countingSource) GetBooks(ctx context.Context, ids []int) ([]Book, error) {
+	s.gets++
+	return s.BookSource.GetBooks(ctx, ids)
+}
+
+func (s *countingSource) ListBooks(q Query) *Iter[Book] {
+	s.lists++
+	return s.BookSource.ListBooks(q)
+}
+
+func TestFallbackSource(t *testing.T) {
+	books := testBooks()
+	local := NewCatalog(books[0])
+	remote := NewCatalog(books...)
+	src := NewFallbackSource(local, remote)
+	ctx := context.Background()
+
+	if b, err := src.GetBook(ctx, 3); err != nil || b.ID != 3 {
+		t.Fatalf("GetBook = %+v, %v", b, err)
+	}
+	got, err := src.GetBooks(ctx, []int{2, 1})
+	if err != nil || len(got) != 2 || got[0].ID != 2 || got[1].ID != 1 {
+		t.Fatalf("GetBooks = %+v, %v", got, err)
+	}
+	if ids := collectIDs(t, src.ListBooks(Query{Author: "Austen"})); len(ids) != 1 || ids[0] != 1 {
+		t.Fatalf("expected local results only, got %v", ids)
+	}
+	if ids := collectIDs(t, src.ListBooks(Query{Author: "Hugo"})); len(ids) != 1 || ids[0] != 3 {
+		t.Fatalf("expected remote results, got %v", ids)
+	}
+}
+
+func TestFallbackSourceCanceled(t *testing.T) {
+	books := testBooks()
+	remote := &countingSource{BookSource: NewCatalog(books...)}
+	src := NewFallbackSource(NewCatalog(books[0]), remote)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := src.GetBook(ctx, 3); err == nil {
+		t.Fatal("GetBook with canceled context succeeded")
+	}
+	if _, err := src.GetBooks(ctx, []int{2, 3}); err == nil {
+		t.Fatal("GetBooks with canceled context succeeded")
+	}
+	if remote.gets != 0 {
+		t.Errorf("secondary consulted %d times after cancellation", remote.gets)
+	}
+}
+
+func TestSourceListBooksCanceled(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	remote := newTestClient(srv.URL)
+
+	for name, mk := range map[string]func(*countingSource) BookSource{
+		"fallback":     func(sec *countingSource) BookSource { return NewFallbackSource(remote, sec) },
+		"caching":      func(*countingSource) BookSource { return NewCachingSource(remote, 0) },
+		"read-through": func(*countingSource) BookSource { return NewReadThroughSource(remote, NewCatalog()) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			secondary := &countingSource{BookSource: NewCatalog(testBooks()...)}
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			done := make(chan error, 1)
+			go func() {
+				_, err := mk(secondary).ListBooks(Query{}).Collect(ctx)
+				done <- err
+			}()
+			select {
+			case err := <-done:
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("Collect error = %v, want deadline exceeded", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Collect did not stop when its context was done")
+			}
+			if secondary.lists != 0 {
+				t.Errorf("secondary listed %d times after cancellation", secondary.lists)
+			}
+		})
+	}
+}
+
+func TestCachingSource(t *testing.T) {
+	inner := &countingSource{BookSource: NewCatalog(testBooks()...)}
+	src := NewCachingSource(inner, time.Minute)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := src.GetBook(ctx, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if inner.gets != 1 {
+		t.Fatalf("expected 1 upstream get, got %d", inner.gets)
+	}
+
+	collectIDs(t, src.ListBooks(Query{}))
+	if _, err := src.GetBooks(ctx, []int{2, 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inner.gets != 1 {
+		t.Fatalf("listed books should be cached, got %d upstream gets", inner.gets)
+	}
+}
+
+func TestCachingSourceCopiesBooks(t *testing.T) {
+	ctx := context.Background()
+	src := NewCachingSource(NewCatalog(testBooks()...), 0)
+	b, err := src.GetBook(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Subjects[0] = "changed"
+	b.Formats["text/plain; charset=us-ascii"] = "changed"
+	again, err := src.GetBook(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Subjects[0] != "Courtship -- Fiction" || again.Formats["text/plain; charset=us-ascii"] != "u" {
+		t.Errorf("cache changed through a caller's book: %+v", again)
+	}
+}
+
+func TestCachingSourceExpiry(t *testing.T) {
+	inner := &countingSource{BookSource: NewCatalog(testBooks()...)}
+	src := NewCachingSource(inner, time.Nanosecond)
+	for range 2 {
+		if _, err := src.GetBook(context.Background(), 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if inner.gets != 2 {
+		t.Fatalf("expected expired entry to be refetched, got %d gets", inner.gets)
+	}
+}
+
+func TestReadThroughSource(t *testing.T) {
+	remote := &countingSource{BookSource: NewCatalog(testBooks()...)}
+	store := NewCatalog()
+	src := NewReadThroughSource(remote, store)
+	ctx := context.Background()
+
+	if _, err := src.GetBook(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := src.GetBook(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remote.gets != 1 || store.Len() != 1 {
+		t.Fatalf("expected single remote fetch stored locally, gets=%d len=%d", remote.gets, store.Len())
+	}
+	if _, err := src.GetBook(ctx, 42); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	got, err := src.GetBooks(ctx, []int{1, 2})
+	if err != nil || len(got) != 2 {
+		t.Fatalf("GetBooks = %+v, %v", got, err)
+	}
+	collectIDs(t, src.ListBooks(Query{Language: "fr"}))
+	if store.Len() != 3 {
+		t.Fatalf("expected listed books in store, len=%d", store.Len())
+	}
+}
@@ -0,0 +1,184 @@
+// Package subject parses the Library of Congress Subject Headings found in
+// Book.Subjects and indexes books by them.
+//
+// A heading such as "England -- Social life and customs -- 19th century --
+// Fiction" consists of a main heading followed by subdivisions, each of
+// which is topical ("Social life and customs"), geographic ("England"),
+// chronological ("19th century") or a form ("Fiction"). Gutenberg records
+// headings as plain text without MARC subfield codes, so the kind of each
+// part is inferred from its wording.
+package subject
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Separator joins the parts of a heading.
+const Separator = " -- "
+
+// Kind classifies a heading part.
+type Kind int
+
+// Kinds of heading parts.
+const (
+	Topical Kind = iota
+	Geographic
+	Chronological
+	Form
+)
+
+var kindNames = [...]string{"topical", "geographic", "chronological", "form"}
+
+// String returns the lowercase name of k, e.g. "geographic".
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "unknown"
+	}
+	return kindNames[k]
+}
+
+// Kinds lists every Kind in order.
+var Kinds = []Kind{Topical, Geographic, Chronological, Form}
+
+// Part is one element of a heading.
+type Part struct {
+	Kind Kind
+	Term string
+}
+
+// Heading is a parsed subject heading. Parts[0] is the main heading.
+type Heading struct {
+	Parts []Part
+}
+
+// Parse splits s into its main heading and subdivisions and classifies
+// each of them.
+func Parse(s string) Heading {
+	var h Heading
+	for _, term := range strings.Split(s, "--") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		h.Parts = append(h.Parts, Part{Kind: classify(term, len(h.Parts) == 0), Term: term})
+	}
+	return h
+}
+
+// String returns the heading in Gutenberg's notation.
+func (h Heading) String() string {
+	terms := make([]string, len(h.Parts))
+	for i, p := range h.Parts {
+		terms[i] = p.Term
+	}
+	return strings.Join(terms, Separator)
+}
+
+// Main returns the main heading, e.g. "England".
+func (h Heading) Main() string {
+	if len(h.Parts) == 0 {
+		return ""
+	}
+	return h.Parts[0].Term
+}
+
+// Terms returns the terms of the given kind, including the main heading.
+func (h Heading) Terms(k Kind) []string {
+	var out []string
+	for _, p := range h.Parts {
+		if p.Kind == k {
+			out = append(out, p.Term)
+		}
+	}
+	return out
+}
+
+// HasPrefix reports whether h equals prefix or is one of its subdivisions,
+// comparing terms case-insensitively. "Science fiction -- History" has the
+// prefix "Science fiction" but "Science fiction, American" does not.
+func (h Heading) HasPrefix(prefix Heading) bool {
+	if len(prefix.Parts) == 0 || len(prefix.Parts) > len(h.Parts) {
+		return false
+	}
+	for i, p := range prefix.Parts {
+		if !strings.EqualFold(p.Term, h.Parts[i].Term) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	// chronological matches "19th century", "1861-1865", "To 1500",
+	// "Civil War, 1861-1865" and similar period terms.
+	chronological = regexp.MustCompile(`(?i)(\b\d{1,2}(st|nd|rd|th) century\b|\b\d{3,4}\b|^to \d|^(ancient|medieval|modern) period)`)
+	// qualified matches a place qualified by its country or state, such as
+	// "London (England)" or "Boston (Mass.)".
+	qualified = regexp.MustCompile(`^[^()]+\(([^()]+)\)$`)
+)
+
+func classify(term string, main bool) Kind {
+	if isPlace(term) {
+		return Geographic
+	}
+	if main {
+		return Topical
+	}
+	lower := strings.ToLower(term)
+	switch {
+	case forms[lower], strings.HasPrefix(lower, "translations into "),
+		strings.HasSuffix(lower, " fiction"), strings.HasSuffix(lower, " literature"):
+		return Form
+	case chronological.MatchString(term):
+		return Chronological
+	}
+	return Topical
+}
+
+// isPlace reports whether term is a known place or a name qualified by
+// one, such as "London (England)" or "Boston (Mass.)". Other qualifiers,
+// as in "Holmes, Sherlock (Fictitious character)", do not make a place.
+func isPlace(term string) bool {
+	if places[term] {
+		return true
+	}
+	m := qualified.FindStringSubmatch(term)
+	return m != nil && (places[m[1]] || stateAbbrevs[m[1]])
+}
+
+// forms lists common LCSH form subdivisions, lowercased.
+var forms = map[string]bool{
+	"anecdotes": true, "bibliography": true, "biography": true, "caricatures and cartoons": true,
+	"comic books, strips, etc.": true, "correspondence": true, "dictionaries": true, "diaries": true,
+	"drama": true, "early works to 1800": true, "fiction": true, "guidebooks": true, "handbooks, manuals, etc.": true,
+	"humor": true, "juvenile fiction": true, "juvenile literature": true, "juvenile poetry": true,
+	"maps": true, "pictorial works": true, "periodicals": true, "poetry": true, "quotations": true,
+	"sermons": true, "songs and music": true, "sources": true, "speeches, addresses, etc.": true,
+	"textbooks": true,
+}
+
+// places lists countries, regions and other frequent geographic headings,
+// together with the qualifiers used in names like "Paris (France)".
+var places = map[string]bool{
+	"Africa": true, "America": true, "Antarctica": true, "Arctic regions": true, "Asia": true,
+	"Australia": true, "Austria": true, "Belgium": true, "Brazil": true, "Canada": true,
+	"China": true, "Denmark": true, "Egypt": true, "England": true, "Europe": true,
+	"Finland": true, "France": true, "Germany": true, "Great Britain": true, "Greece": true,
+	"India": true, "Ireland": true, "Israel": true, "Italy": true, "Japan": true,
+	"Mexico": true, "Netherlands": true, "New England": true, "New York (N.Y.)": true,
+	"Norway": true, "Palestine": true, "Poland": true, "Portugal": true, "Rome": true,
+	"Russia": true, "Scotland": true, "Spain": true, "Sweden": true, "Switzerland": true,
+	"Turkey": true, "United States": true, "Wales": true, "West (U.S.)": true,
+}
+
+// stateAbbrevs lists qualifiers for places in the United States and
+// Canada, as used in "Boston (Mass.)".
+var stateAbbrevs = map[string]bool{
+	"Ala.": true, "Calif.": true, "Colo.": true, "Conn.": true, "D.C.": true, "Fla.": true,
+	"Ga.": true, "Ill.": true, "Ind.": true, "Ky.": true, "La.": true, "Mass.": true,
+	"Md.": true, "Me.": true, "Mich.": true, "Minn.": true, "Miss.": true, "Mo.": true,
+	"N.C.": true, "N.H.": true, "N.J.": true, "N.Y.": true, "Ohio": true, "Ont.": true,
+	"Pa.": true, "Que.": true, "S.C.": true, "Tenn.": true, "Tex.": true, "U.S.": true,
+	"Va.": true, "Vt.": true, "Wis.": true,
+}
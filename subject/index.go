@@ -0,0 +1,266 @@
+package subject
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// Facet is a term together with the number of books it applies to.
+type Facet struct {
+	Term  string
+	Count int
+}
+
+// Index is a faceted index of books by the parts of their subject
+// headings, with a browsable hierarchy of main headings and subdivisions.
+// Terms are matched case-insensitively. An Index is not safe for concurrent
+// modification.
+type Index struct {
+	books []gutendex.Book
+	ids   map[int]bool
+	terms [len(kindNames)]map[string]*posting
+	root  Node
+}
+
+// posting lists the positions of the books a term applies to.
+type posting struct {
+	term  string
+	books []int
+}
+
+// addPosition appends book position i to positions unless it is already
+// the last entry. Positions are added in increasing order.
+func addPosition(positions []int, i int) []int {
+	if n := len(positions); n == 0 || positions[n-1] != i {
+		return append(positions, i)
+	}
+	return positions
+}
+
+// Node is an entry of the subject hierarchy: a main heading, or a
+// subdivision below its parent.
+type Node struct {
+	Part
+	// Heading is the full heading down to this node, e.g.
+	// "England -- Social life and customs".
+	Heading string
+
+	children map[string]*Node
+	books    []int
+}
+
+// Count returns the number of books filed under the node or any of its
+// descendants.
+func (n *Node) Count() int { return len(n.books) }
+
+// Children returns the subdivisions below n, most books first.
+func (n *Node) Children() []*Node {
+	out := make([]*Node, 0, len(n.children))
+	for _, c := range n.children {
+		out = append(out, c)
+	}
+	slices.SortFunc(out, func(a, b *Node) int {
+		if c := cmp.Compare(b.Count(), a.Count()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Term, b.Term)
+	})
+	return out
+}
+
+// NewIndex returns an index of books.
+func NewIndex(books ...gutendex.Book) *Index {
+	x := &Index{ids: make(map[int]bool)}
+	for k := range x.terms {
+		x.terms[k] = make(map[string]*posting)
+	}
+	x.Add(books...)
+	return x
+}
+
+// Build indexes every book produced by it. Set the iterator's context with
+// Iter.Context to be able to cancel a build.
+func Build(it *gutendex.Iter[gutendex.Book]) (*Index, error) {
+	x := NewIndex()
+	for it.Next() {
+		x.Add(it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Add indexes books. Books whose ID is already indexed are ignored.
+func (x *Index) Add(books ...gutendex.Book) {
+	for _, b := range books {
+		if x.ids[b.ID] {
+			continue
+		}
+		x.ids[b.ID] = true
+		i := len(x.books)
+		x.books = append(x.books, b)
+		for _, s := range b.Subjects {
+			h := Parse(s)
+			node := &x.root
+			for _, p := range h.Parts {
+				t := x.termPosting(p)
+				t.books = addPosition(t.books, i)
+				key := strings.ToLower(p.Term)
+				child, ok := node.children[key]
+				if !ok {
+					heading := p.Term
+					if node != &x.root {
+						heading = node.Heading + Separator + p.Term
+					}
+					child = &Node{Part: p, Heading: heading}
+					if node.children == nil {
+						node.children = make(map[string]*Node)
+					}
+					node.children[key] = child
+				}
+				child.books = addPosition(child.books, i)
+				node = child
+			}
+		}
+	}
+}
+
+func (x *Index) termPosting(p Part) *posting {
+	key := strings.ToLower(p.Term)
+	t, ok := x.terms[p.Kind][key]
+	if !ok {
+		t = &posting{term: p.Term}
+		x.terms[p.Kind][key] = t
+	}
+	return t
+}
+
+// Len returns the number of indexed books.
+func (x *Index) Len() int { return len(x.books) }
+
+// Facets returns the terms of kind k with their book counts, most books
+// first.
+func (x *Index) Facets(k Kind) []Facet {
+	if k < 0 || int(k) >= len(x.terms) {
+		return nil
+	}
+	out := make([]Facet, 0, len(x.terms[k]))
+	for _, t := range x.terms[k] {
+		out = append(out, Facet{Term: t.term, Count: len(t.books)})
+	}
+	slices.SortFunc(out, func(a, b Facet) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Term, b.Term)
+	})
+	return out
+}
+
+// Select returns the books matching every given part, in the order they
+// were added. With no parts it returns every book.
+func (x *Index) Select(parts ...Part) []gutendex.Book {
+	var sel []int
+	for i, p := range parts {
+		if p.Kind < 0 || int(p.Kind) >= len(x.terms) {
+			return nil
+		}
+		t, ok := x.terms[p.Kind][strings.ToLower(p.Term)]
+		if !ok {
+			return nil
+		}
+		if i == 0 {
+			sel = t.books
+		} else {
+			sel = intersect(sel, t.books)
+		}
+	}
+	if len(parts) == 0 {
+		return slices.Clone(x.books)
+	}
+	return x.collect(sel)
+}
+
+// Roots returns the main headings, most books first.
+func (x *Index) Roots() []*Node { return x.root.Children() }
+
+// Lookup returns the hierarchy node for heading, or nil if no book is filed
+// under it.
+func (x *Index) Lookup(heading string) *Node {
+	node := &x.root
+	for _, p := range Parse(heading).Parts {
+		node = node.children[strings.ToLower(p.Term)]
+		if node == nil {
+			return nil
+		}
+	}
+	if node == &x.root {
+		return nil
+	}
+	return node
+}
+
+// BooksUnder returns the books filed under heading or any of its
+// subdivisions, in the order they were added. For example, "Science
+// fiction" covers "Science fiction -- History and criticism".
+func (x *Index) BooksUnder(heading string) []gutendex.Book {
+	n := x.Lookup(heading)
+	if n == nil {
+		return nil
+	}
+	return x.collect(n.books)
+}
+
+func (x *Index) collect(positions []int) []gutendex.Book {
+	out := make([]gutendex.Book, len(positions))
+	for i, p := range positions {
+		out[i] = x.books[p]
+	}
+	return out
+}
+
+// intersect returns the common elements of two sorted slices.
+func intersect(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// BooksUnder lists the books of src filed under heading or any of its
+// subdivisions. It asks src for books on the topic and keeps those with a
+// matching heading, so that a search for "Science fiction" excludes
+// "Science fiction, American" and books merely shelved under it.
+func BooksUnder(ctx context.Context, src gutendex.BookSource, heading string) ([]gutendex.Book, error) {
+	want := Parse(heading)
+	var out []gutendex.Book
+	it := src.ListBooks(gutendex.Query{Topic: want.String()}).Context(ctx)
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		b := it.Value()
+		if slices.ContainsFunc(b.Subjects, func(s string) bool { return Parse(s).HasPrefix(want) }) {
+			out = append(out, b)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,127 @@
+package subject
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Part
+	}{
+		{"England -- Social life and customs -- 19th century -- Fiction", []Part{
+			{Geographic, "England"}, {Topical, "Social life and customs"}, {Chronological, "19th century"}, {Form, "Fiction"},
+		}},
+		{"United States -- History -- Civil War, 1861-1865 -- Juvenile fiction", []Part{
+			{Geographic, "United States"}, {Topical, "History"}, {Chronological, "Civil War, 1861-1865"}, {Form, "Juvenile fiction"},
+		}},
+		{"Science fiction", []Part{{Topical, "Science fiction"}}},
+		{"Holmes, Sherlock (Fictitious character) -- Fiction", []Part{
+			{Topical, "Holmes, Sherlock (Fictitious character)"}, {Form, "Fiction"},
+		}},
+		{"Whaling -- Boston (Mass.) -- Early works to 1800", []Part{
+			{Topical, "Whaling"}, {Geographic, "Boston (Mass.)"}, {Form, "Early works to 1800"},
+		}},
+		{"Greek poetry -- Translations into English", []Part{
+			{Topical, "Greek poetry"}, {Form, "Translations into English"},
+		}},
+	}
+	for _, tt := range tests {
+		h := Parse(tt.in)
+		if !reflect.DeepEqual(h.Parts, tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.in, h.Parts, tt.want)
+		}
+		if h.String() != tt.in {
+			t.Errorf("String() = %q, want %q", h.String(), tt.in)
+		}
+	}
+}
+
+func TestHasPrefix(t *testing.T) {
+	h := Parse("Science fiction -- History and criticism")
+	if !h.HasPrefix(Parse("science fiction")) {
+		t.Error("expected prefix match")
+	}
+	if Parse("Science fiction, American").HasPrefix(Parse("Science fiction")) {
+		t.Error("a different main heading is not a subdivision")
+	}
+}
+
+var books = []gutendex.Book{
+	{ID: 1, Subjects: []string{"England -- Social life and customs -- 19th century -- Fiction", "Courtship -- Fiction"}},
+	{ID: 2, Subjects: []string{"Science fiction", "Time travel -- Fiction"}},
+	{ID: 3, Subjects: []string{"Science fiction -- History and criticism"}},
+	{ID: 4, Subjects: []string{"England -- Description and travel"}},
+	{ID: 5, Subjects: []string{"Science fiction, American"}, Bookshelves: []string{"Science Fiction"}},
+}
+
+func TestIndex(t *testing.T) {
+	x := NewIndex(books...)
+	x.Add(books[0])
+	if x.Len() != 5 {
+		t.Fatalf("Len = %d", x.Len())
+	}
+	if got, want := x.Facets(Geographic), []Facet{{"England", 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("geographic facets = %v, want %v", got, want)
+	}
+	if got, want := x.Facets(Form), []Facet{{"Fiction", 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("form facets = %v, want %v", got, want)
+	}
+	if got := ids(x.Select(Part{Geographic, "england"}, Part{Form, "Fiction"})); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Select = %v", got)
+	}
+	if got := ids(x.BooksUnder("Science fiction")); !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Errorf("BooksUnder = %v", got)
+	}
+
+	roots := x.Roots()
+	if roots[0].Term != "England" || roots[0].Count() != 2 {
+		t.Fatalf("first root = %+v", roots[0])
+	}
+	n := x.Lookup("England -- Social life and customs")
+	if n == nil || n.Kind != Topical || n.Count() != 1 {
+		t.Fatalf("Lookup = %+v", n)
+	}
+	if c := n.Children(); len(c) != 1 || c[0].Heading != "England -- Social life and customs -- 19th century" {
+		t.Fatalf("children = %+v", c)
+	}
+	if x.Lookup("Poetry") != nil {
+		t.Fatal("unexpected node for unknown heading")
+	}
+}
+
+func TestBooksUnder(t *testing.T) {
+	src := gutendex.NewCatalog(books...)
+	got, err := BooksUnder(context.Background(), src, "Science fiction")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := ids(got); len(ids) != 2 || !containsAll(ids, 2, 3) {
+		t.Fatalf("BooksUnder = %v", ids)
+	}
+}
+
+func ids(books []gutendex.Book) []int {
+	var out []int
+	for _, b := range books {
+		out = append(out, b.ID)
+	}
+	return out
+}
+
+func containsAll(ids []int, want ...int) bool {
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		seen[id] = true
+	}
+	for _, id := range want {
+		if !seen[id] {
+			return false
+		}
+	}
+	return true
+}
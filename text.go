@@ -0,0 +1,202 @@
+package gutendex
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	internal "github.com/alex-rs/go-gutendex/internal"
+)
+
+// textFormats lists the plain-text formats tried by TextURL, best first.
+var textFormats = []string{
+	"text/plain; charset=utf-8",
+	"text/plain; charset=us-ascii",
+	"text/plain; charset=iso-8859-1",
+	"text/plain",
+}
+
+// TextURL returns the URL of the book's plain-text download, preferring
+// UTF-8, or "" if the book has none. Zipped downloads are not considered.
+func (b Book) TextURL() string {
+	for _, mime := range textFormats {
+		if u := b.Formats[mime]; u != "" && !strings.HasSuffix(u, ".zip") {
+			return u
+		}
+	}
+	return ""
+}
+
+// OpenText downloads the plain text of b. The caller must close the
+// returned reader. The text includes the Project Gutenberg header and
+// license; wrap it in StripBoilerplate to remove them. OpenText reports a
+// not-found error if b has no plain-text format. Texts are not kept in the
+// client's response cache, which holds whole responses in memory.
+func (c *Client) OpenText(ctx context.Context, b Book) (_ io.ReadCloser, err error) {
+	ctx, span := c.hc.StartSpan(ctx, "gutendex.OpenText", internal.Attribute{Key: "gutendex.book_id", Value: b.ID})
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
+	u := b.TextURL()
+	if u == "" {
+		return nil, &Error{Op: "OpenText", Kind: ErrNotFound, Err: fmt.Errorf("book %d has no plain-text format", b.ID)}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, &Error{Op: "OpenText", Kind: ErrNetwork, Err: err}
+	}
+	req.Header.Set("Cache-Control", "no-store")
+	resp, err := c.hc.Do(ctx, req)
+	if err != nil {
+		return nil, &Error{Op: "OpenText", Kind: ErrNetwork, Err: err}
+	}
+	span.SetAttributes(
+		internal.Attribute{Key: "http.status_code", Value: resp.StatusCode},
+		internal.Attribute{Key: "gutendex.cache", Value: cacheStatus(resp)},
+	)
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		kind := ErrServer
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			kind = ErrNotFound
+		case http.StatusTooManyRequests:
+			kind = ErrRateLimited
+		}
+		return nil, &Error{Op: "OpenText", Kind: kind, Err: fmt.Errorf("status %d", resp.StatusCode)}
+	}
+	return resp.Body, nil
+}
+
+// headerLimit bounds the number of bytes StripBoilerplate searches for the
+// start of a text. Texts without a start marker within it are passed
+// through from the beginning.
+const headerLimit = 64 << 10
+
+// StripBoilerplate returns a reader of the text read from r without the
+// Project Gutenberg header before the "*** START OF ..." line and the
+// license after the "*** END OF ..." line. Line endings are normalized to
+// "\n". The text is streamed in pieces of bounded size, however long its
+// lines, so only the header is ever buffered.
+func StripBoilerplate(r io.Reader) io.Reader {
+	return &stripReader{r: bufio.NewReader(r)}
+}
+
+type stripReader struct {
+	r       *bufio.Reader
+	started bool
+	done    bool
+	// midLine is set while a line longer than the buffer is read in
+	// pieces; cr holds back a '\r' ending a piece until the next shows
+	// whether it ends the line.
+	midLine bool
+	cr      bool
+	pending []byte
+	err     error
+}
+
+func (s *stripReader) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		if s.done {
+			return 0, io.EOF
+		}
+		if s.err != nil {
+			return 0, s.err
+		}
+		if !s.started {
+			s.started = true
+			s.pending, s.err = s.skipHeader()
+			continue
+		}
+		line, start, err := s.readLine()
+		if err != nil {
+			s.err = err
+		}
+		if start && isEndMarker(line) {
+			s.done = true
+			continue
+		}
+		s.pending = line
+	}
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+// skipHeader consumes the header and returns the text read past it. If no
+// start marker appears within headerLimit bytes, it returns everything
+// read so far.
+func (s *stripReader) skipHeader() ([]byte, error) {
+	var head []byte
+	for len(head) < headerLimit {
+		line, start, err := s.readLine()
+		if start && isStartMarker(line) {
+			return nil, err
+		}
+		if start && isEndMarker(line) {
+			s.done = true
+			return head, err
+		}
+		head = append(head, line...)
+		if err != nil {
+			return head, err
+		}
+	}
+	return head, nil
+}
+
+// readLine returns the next line with its line ending normalized to "\n",
+// and whether it starts a line. A line longer than the reader's buffer is
+// returned in pieces, so memory use stays bounded whatever the input;
+// markers are only looked for at the start of a line.
+func (s *stripReader) readLine() (line []byte, start bool, err error) {
+	start = !s.midLine
+	chunk, err := s.r.ReadSlice('\n')
+	s.midLine = err == bufio.ErrBufferFull
+	if s.midLine {
+		err = nil
+	}
+	line = make([]byte, 0, len(chunk)+1)
+	if s.cr {
+		if len(chunk) == 0 || chunk[0] != '\n' {
+			line = append(line, '\r')
+		}
+		s.cr = false
+	}
+	line = append(line, chunk...)
+	if l, ok := bytes.CutSuffix(line, []byte("\r")); ok && s.midLine {
+		line, s.cr = l, true
+	} else if l, ok := bytes.CutSuffix(line, []byte("\r\n")); ok {
+		line = append(l, '\n')
+	}
+	return line, start, err
+}
+
+func isStartMarker(line []byte) bool {
+	s := markerCandidate(line)
+	return strings.HasPrefix(s, "*** START OF") || strings.HasPrefix(s, "***START OF") ||
+		strings.HasPrefix(s, "*END*THE SMALL PRINT")
+}
+
+func isEndMarker(line []byte) bool {
+	s := markerCandidate(line)
+	return strings.HasPrefix(s, "*** END OF") || strings.HasPrefix(s, "***END OF") ||
+		strings.HasPrefix(s, "END OF THE PROJECT GUTENBERG") || strings.HasPrefix(s, "END OF PROJECT GUTENBERG")
+}
+
+// markerCandidate returns line trimmed and upper-cased if it may be a
+// marker line, or "" without allocating for the common text line.
+func markerCandidate(line []byte) string {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '*' && line[0] != 'E' && line[0] != 'e' {
+		return ""
+	}
+	return strings.ToUpper(string(line))
+}
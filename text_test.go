@@ -0,0 +1,165 @@
+package gutendex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const gutenbergText = "\ufeffThe Project Gutenberg eBook of Emma\r\n" +
+	"\r\n" +
+	"This eBook is for the use of anyone anywhere.\r\n" +
+	"*** START OF THE PROJECT GUTENBERG EBOOK EMMA ***\r\n" +
+	"EMMA\r\n" +
+	"\r\n" +
+	"Emma Woodhouse, handsome, clever, and rich.\r\n" +
+	"*** END OF THE PROJECT GUTENBERG EBOOK EMMA ***\r\n" +
+	"Section 1. General Terms of Use\r\n"
+
+func TestStripBoilerplate(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"markers", gutenbergText, "EMMA\n\nEmma Woodhouse, handsome, clever, and rich.\n"},
+		{"no markers", "Just a text\nwithout header", "Just a text\nwithout header"},
+		{"old style", "header\n*END*THE SMALL PRINT! FOR PUBLIC DOMAIN ETEXTS*\nText\nEnd of the Project Gutenberg Etext\nlicense\n", "Text\n"},
+		{"end only", "Text\n*** END OF THIS PROJECT GUTENBERG EBOOK ***\nlicense\n", "Text\n"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(StripBoilerplate(iotest.OneByteReader(strings.NewReader(tt.in))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripBoilerplateLongHeader(t *testing.T) {
+	body := strings.Repeat("no marker here\n", headerLimit/10)
+	got, err := io.ReadAll(StripBoilerplate(strings.NewReader(body)))
+	if err != nil || string(got) != body {
+		t.Fatalf("got %d bytes, %v; want %d bytes", len(got), err, len(body))
+	}
+}
+
+func TestTextURL(t *testing.T) {
+	b := Book{Formats: map[string]string{
+		"text/plain; charset=us-ascii": "https://example.org/158.txt",
+		"text/plain; charset=utf-8":    "https://example.org/158-0.zip",
+		"application/epub+zip":         "https://example.org/158.epub",
+	}}
+	if got := b.TextURL(); got != "https://example.org/158.txt" {
+		t.Errorf("TextURL = %q", got)
+	}
+	if got := (Book{}).TextURL(); got != "" {
+		t.Errorf("TextURL without formats = %q", got)
+	}
+}
+
+func TestOpenText(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/158.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, gutenbergText)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	ctx := context.Background()
+	rc, err := c.OpenText(ctx, Book{ID: 158, Formats: map[string]string{"text/plain": srv.URL + "/158.txt"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(StripBoilerplate(rc))
+	if cerr := rc.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || !strings.HasPrefix(string(got), "EMMA\n") {
+		t.Fatalf("OpenText = %q, %v", got, err)
+	}
+
+	if _, err := c.OpenText(ctx, Book{ID: 1}); !IsNotFound(err) {
+		t.Errorf("no text format: expected not found, got %v", err)
+	}
+	if _, err := c.OpenText(ctx, Book{ID: 2, Formats: map[string]string{"text/plain": srv.URL + "/missing.txt"}}); !IsNotFound(err) {
+		t.Errorf("missing text: expected not found, got %v", err)
+	}
+}
+
+func TestOpenTextNotCached(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=3600")
+		_, _ = fmt.Fprint(w, gutenbergText)
+	}))
+	defer srv.Close()
+
+	tr := &recordingTracer{}
+	c := newTestClient(srv.URL)
+	WithTracer(tr)(c)
+	b := Book{ID: 158, Formats: map[string]string{"text/plain": srv.URL + "/158.txt"}}
+	for range 2 {
+		rc, err := c.OpenText(context.Background(), b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.Copy(io.Discard, rc); err != nil {
+			t.Fatal(err)
+		}
+		_ = rc.Close()
+	}
+	if requests != 2 {
+		t.Errorf("server saw %d requests, want 2: text was cached", requests)
+	}
+
+	spans := tr.byName("gutendex.OpenText")
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 OpenText spans, got %d", len(spans))
+	}
+	for _, s := range spans {
+		if !s.ended || s.err != nil || s.attrs["gutendex.book_id"] != 158 ||
+			s.attrs["http.status_code"] != 200 || s.attrs["gutendex.cache"] != "miss" {
+			t.Errorf("unexpected span: %+v", s)
+		}
+	}
+	if _, err := c.OpenText(context.Background(), Book{ID: 1}); err == nil {
+		t.Fatal("expected error")
+	}
+	if s := tr.byName("gutendex.OpenText"); len(s) != 3 || !IsNotFound(s[2].err) {
+		t.Errorf("error not recorded on span: %+v", s)
+	}
+}
+
+func TestStripBoilerplateLongLines(t *testing.T) {
+	long := strings.Repeat("x", 1<<20)
+	// A marker continuing a long line is text, and a "\r\n" split between
+	// pieces of a line is still normalized.
+	in := "header\n*** START OF THE PROJECT GUTENBERG EBOOK ***\n" +
+		long + "\n" +
+		strings.Repeat("y", 4096) + "*** END OF THE PROJECT GUTENBERG EBOOK ***\n" +
+		strings.Repeat("z", 4095) + "\r\n" +
+		"*** END OF THE PROJECT GUTENBERG EBOOK ***\nlicense\n"
+	want := long + "\n" +
+		strings.Repeat("y", 4096) + "*** END OF THE PROJECT GUTENBERG EBOOK ***\n" +
+		strings.Repeat("z", 4095) + "\n"
+	got, err := io.ReadAll(StripBoilerplate(strings.NewReader(in)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("got %d bytes, want %d", len(got), len(want))
+	}
+}
@@ -0,0 +1,88 @@
+package textstats
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// syllables estimates the syllables of a lower-case English word by
+// counting groups of vowels, adding vowel pairs pronounced apart, as in
+// "usual" or "naïve", and discounting a silent final "e" and the "ed" of
+// words like "jumped". Every word has at least one syllable. The word is
+// handled as runes so accented letters count as one letter.
+func syllables(w string) int {
+	rs := []rune(w)
+	n := 0
+	prevVowel := false
+	for _, r := range rs {
+		v := isVowel(r)
+		if v && (!prevVowel || isDiaeresis(r)) {
+			n++
+		}
+		prevVowel = v
+	}
+	n += hiatuses(rs)
+	// before is the letter preceding a two-letter ending.
+	var before rune
+	if len(rs) > 2 {
+		before = rs[len(rs)-3]
+	}
+	switch {
+	case n <= 1:
+	case strings.HasSuffix(w, "le") && before != 0 && !isVowel(before):
+		// "table", "little": the final "le" is a syllable of its own.
+	case strings.HasSuffix(w, "e") && !strings.HasSuffix(w, "ee") && !strings.HasSuffix(w, "ye"):
+		n--
+	case strings.HasSuffix(w, "ed") && before != 0 && !strings.ContainsRune("td", before) && !isVowel(before):
+		n--
+	case strings.HasSuffix(w, "es") && before != 0 && !strings.ContainsRune("sxzcg", before) && !isVowel(before):
+		// "makes" but not "horses" or "boxes".
+		n--
+	}
+	return max(n, 1)
+}
+
+// hiatusPairs lists vowel pairs usually pronounced as two syllables,
+// unless preceded by a letter in the mapped set: "io" is split in "violin"
+// but not in "nation".
+var hiatusPairs = map[string]string{
+	"ia": "cstxg", "io": "cstxg", "eo": "g", "ua": "qg", "uo": "q", "iu": "",
+}
+
+// hiatuses counts the vowel pairs of rs pronounced apart, including a
+// vowel before "ing", as in "doing".
+func hiatuses(rs []rune) int {
+	n := 0
+	for i := 0; i+1 < len(rs); i++ {
+		not, ok := hiatusPairs[string(rs[i:i+2])]
+		if ok && (i == 0 || !strings.ContainsRune(not, rs[i-1])) {
+			n++
+		}
+	}
+	if k := len(rs) - 3; k > 0 && string(rs[k:]) == "ing" && isVowel(rs[k-1]) {
+		n++
+	}
+	return n
+}
+
+// isDiaeresis reports whether r carries a diaeresis, which marks a vowel
+// pronounced apart from the one before it, as in "naïve" or "Zoë".
+func isDiaeresis(r rune) bool {
+	return strings.ContainsRune("äëïöüÿ", r)
+}
+
+// isVowel reports whether r is a vowel, including "y" and accented vowels.
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeiouyàáâãäåæèéêëìíîïòóôõöøùúûüýÿœ", r)
+}
+
+// trimSuffix removes the endings -es, -ed and -ing, which do not make a
+// word complex for the Gunning fog index.
+func trimSuffix(w string) string {
+	for _, s := range []string{"ing", "ed", "es"} {
+		if rest, ok := strings.CutSuffix(w, s); ok && utf8.RuneCountInString(rest) > 2 {
+			return rest
+		}
+	}
+	return w
+}
@@ -0,0 +1,312 @@
+// Package textstats measures the length, vocabulary and readability of
+// book texts, for grading books by difficulty.
+//
+// Texts are analyzed as a stream: an Analyzer keeps counts and the set of
+// distinct words, never the text itself, so a multi-megabyte download can
+// be measured while it is read. Syllables are counted with a heuristic for
+// English; scores for other languages are only indicative.
+package textstats
+
+import (
+	"context"
+	"io"
+	"slices"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+// DefaultWordsPerMinute is the silent reading speed of an adult reading
+// fiction in English, used by ReadingTime.
+const DefaultWordsPerMinute = 238
+
+// Stats holds the counts of a text from which its statistics and scores
+// are derived.
+type Stats struct {
+	Words     int
+	Sentences int
+	// Letters counts the letters and digits of words.
+	Letters   int
+	Syllables int
+	// ComplexWords counts words of three or more syllables, not counting
+	// capitalized words, hyphenated compounds and the endings -es, -ed and
+	// -ing, as for the Gunning fog index.
+	ComplexWords int
+	// Vocabulary counts distinct words, ignoring case.
+	Vocabulary int
+}
+
+// Analyze reads a text from r and returns its statistics.
+func Analyze(r io.Reader) (Stats, error) {
+	a := NewAnalyzer()
+	if _, err := io.Copy(a, r); err != nil {
+		return Stats{}, err
+	}
+	return a.Stats(), nil
+}
+
+// AnalyzeBook downloads the plain text of b and returns its statistics,
+// without the Project Gutenberg header and license.
+func AnalyzeBook(ctx context.Context, c *gutendex.Client, b gutendex.Book) (Stats, error) {
+	rc, err := c.OpenText(ctx, b)
+	if err != nil {
+		return Stats{}, err
+	}
+	s, err := Analyze(gutendex.StripBoilerplate(rc))
+	if cerr := rc.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return Stats{}, err
+	}
+	return s, nil
+}
+
+// TypeTokenRatio returns the number of distinct words per word, a measure
+// of lexical variety. It falls as texts grow longer, so compare it only
+// between texts of similar length.
+func (s Stats) TypeTokenRatio() float64 { return ratio(s.Vocabulary, s.Words) }
+
+// AvgWordLength returns the mean number of letters per word.
+func (s Stats) AvgWordLength() float64 { return ratio(s.Letters, s.Words) }
+
+// AvgSentenceLength returns the mean number of words per sentence.
+func (s Stats) AvgSentenceLength() float64 { return ratio(s.Words, s.Sentences) }
+
+// AvgSyllables returns the mean number of syllables per word.
+func (s Stats) AvgSyllables() float64 { return ratio(s.Syllables, s.Words) }
+
+// FleschKincaid returns the Flesch–Kincaid grade level: the U.S. school
+// grade whose pupils could follow the text.
+func (s Stats) FleschKincaid() float64 {
+	if s.Words == 0 || s.Sentences == 0 {
+		return 0
+	}
+	return 0.39*s.AvgSentenceLength() + 11.8*s.AvgSyllables() - 15.59
+}
+
+// FleschReadingEase returns the Flesch reading-ease score, from about 100
+// for very easy texts down to 0 and below for very difficult ones.
+func (s Stats) FleschReadingEase() float64 {
+	if s.Words == 0 || s.Sentences == 0 {
+		return 0
+	}
+	return 206.835 - 1.015*s.AvgSentenceLength() - 84.6*s.AvgSyllables()
+}
+
+// GunningFog returns the Gunning fog index, the years of formal education
+// needed to understand the text on first reading.
+func (s Stats) GunningFog() float64 {
+	if s.Words == 0 || s.Sentences == 0 {
+		return 0
+	}
+	return 0.4 * (s.AvgSentenceLength() + 100*ratio(s.ComplexWords, s.Words))
+}
+
+// ColemanLiau returns the Coleman–Liau index, a grade level computed from
+// letters rather than syllables.
+func (s Stats) ColemanLiau() float64 {
+	if s.Words == 0 {
+		return 0
+	}
+	l := 100 * ratio(s.Letters, s.Words)
+	sen := 100 * ratio(s.Sentences, s.Words)
+	return 0.0588*l - 0.296*sen - 15.8
+}
+
+// ReadingTime estimates the time to read the text at wpm words per
+// minute, or at DefaultWordsPerMinute if wpm is not positive.
+func (s Stats) ReadingTime(wpm int) time.Duration {
+	if wpm <= 0 {
+		wpm = DefaultWordsPerMinute
+	}
+	return time.Duration(float64(s.Words) / float64(wpm) * float64(time.Minute)).Round(time.Second)
+}
+
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
+
+// Analyzer accumulates statistics of a text written to it in pieces of
+// any size. It is not safe for concurrent use.
+type Analyzer struct {
+	stats Stats
+	vocab map[string]struct{}
+
+	// partial holds the bytes of a rune split between writes.
+	partial []byte
+	// word holds the current word; joiner is a pending apostrophe, hyphen
+	// or separator within a number that continues it if a letter or digit
+	// follows.
+	word     []rune
+	joiner   rune
+	hyphen   bool
+	lastWord string
+	inWords  int
+	terminal bool
+	newlines int
+}
+
+// NewAnalyzer returns an Analyzer for a new text.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{vocab: make(map[string]struct{})}
+}
+
+// Write adds p to the text. It never fails.
+func (a *Analyzer) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(a.partial) > 0 {
+		p = append(a.partial, p...)
+		a.partial = nil
+	}
+	for len(p) > 0 {
+		if !utf8.FullRune(p) {
+			a.partial = append([]byte(nil), p...)
+			break
+		}
+		r, size := utf8.DecodeRune(p)
+		a.rune(r)
+		p = p[size:]
+	}
+	return n, nil
+}
+
+// WriteString adds s to the text.
+func (a *Analyzer) WriteString(s string) (int, error) {
+	return a.Write([]byte(s))
+}
+
+// Stats returns the statistics of the text written so far, as if it ended
+// there: a sentence without final punctuation is counted as one. Writing
+// may continue afterwards.
+func (a *Analyzer) Stats() Stats {
+	// Finish the pending word and sentence on a copy; a word it completes
+	// goes to a vocabulary of its own.
+	b := *a
+	b.word = slices.Clone(a.word)
+	b.vocab = make(map[string]struct{})
+	if len(b.partial) > 0 {
+		b.rune(utf8.RuneError)
+	}
+	b.endWord()
+	b.endSentence()
+	s := b.stats
+	s.Vocabulary = len(a.vocab)
+	for w := range b.vocab {
+		if _, ok := a.vocab[w]; !ok {
+			s.Vocabulary++
+		}
+	}
+	return s
+}
+
+func (a *Analyzer) rune(r rune) {
+	isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+	if a.joiner == '.' && !isWordRune {
+		// A number followed by a period, as in "in 1895.", ends the
+		// sentence like a word would.
+		a.endWord()
+		a.terminal = a.inWords > 0
+	}
+	switch {
+	case isWordRune:
+		if a.joiner != 0 {
+			a.hyphen = a.hyphen || a.joiner == '-'
+			a.joiner = 0
+		}
+		// A period followed by a letter, as in "3.14" or "e.g", does not
+		// end a sentence.
+		a.terminal = false
+		a.newlines = 0
+		a.word = append(a.word, r)
+	case (r == '\'' || r == '’' || r == '-') && len(a.word) > 0 && a.joiner == 0:
+		a.joiner = r
+	case (r == '.' || r == ',') && len(a.word) > 0 && a.joiner == 0 && unicode.IsDigit(a.word[len(a.word)-1]):
+		// A decimal point or thousands separator, as in "3.14" and
+		// "1,000", if a digit follows.
+		a.joiner = r
+	case r == '.' || r == '!' || r == '?':
+		a.endWord()
+		a.newlines = 0
+		if a.inWords > 0 && (r != '.' || !isAbbreviation(a.lastWord)) {
+			a.terminal = true
+		}
+	case unicode.IsSpace(r):
+		a.endWord()
+		if a.terminal {
+			a.endSentence()
+		}
+		if r == '\n' {
+			// A blank line ends a heading or other unpunctuated line.
+			if a.newlines++; a.newlines == 2 {
+				a.endSentence()
+			}
+		}
+	default:
+		// Closing quotes and brackets after a period keep the sentence
+		// end pending.
+		a.endWord()
+		a.newlines = 0
+	}
+}
+
+func (a *Analyzer) endWord() {
+	a.joiner = 0
+	if len(a.word) == 0 {
+		return
+	}
+	w := string(a.word)
+	lower := toLower(a.word)
+	a.stats.Words++
+	a.stats.Letters += len(a.word)
+	syl := syllables(lower)
+	a.stats.Syllables += syl
+	if !a.hyphen && !unicode.IsUpper(a.word[0]) && syllables(trimSuffix(lower)) >= 3 {
+		a.stats.ComplexWords++
+	}
+	a.vocab[lower] = struct{}{}
+	a.lastWord = w
+	a.inWords++
+	a.word = a.word[:0]
+	a.hyphen = false
+}
+
+func (a *Analyzer) endSentence() {
+	if a.inWords > 0 {
+		a.stats.Sentences++
+	}
+	a.inWords = 0
+	a.terminal = false
+}
+
+func toLower(word []rune) string {
+	out := make([]rune, len(word))
+	for i, r := range word {
+		out[i] = unicode.ToLower(r)
+	}
+	return string(out)
+}
+
+// abbreviations lists words commonly followed by a period within a
+// sentence.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "messrs": true, "ms": true, "dr": true, "st": true,
+	"jr": true, "sr": true, "prof": true, "rev": true, "hon": true, "gen": true,
+	"col": true, "capt": true, "lt": true, "sgt": true, "vol": true, "ch": true,
+	"viz": true, "cf": true, "vs": true, "esq": true,
+}
+
+// isAbbreviation reports whether w, followed by a period, is likely an
+// abbreviation or an initial rather than the end of a sentence.
+func isAbbreviation(w string) bool {
+	if utf8.RuneCountInString(w) == 1 {
+		// Initials, as in "H. G. Wells", but not the words "I" and "A".
+		return w != "I" && w != "A"
+	}
+	return abbreviations[toLower([]rune(w))]
+}
@@ -0,0 +1,188 @@
+package textstats
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	gutendex "github.com/alex-rs/go-gutendex"
+)
+
+func TestAnalyzeCounts(t *testing.T) {
+	text := "CHAPTER I\n\nMr. Bennet was among the earliest of those who waited on Mr. Bingley. " +
+		"He had always intended to visit him! Did he? H. G. Wells wrote it, e.g. in 1895.\n"
+	s, err := Analyze(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Stats{Words: 33, Sentences: 5, Vocabulary: 30}
+	if s.Words != want.Words || s.Sentences != want.Sentences || s.Vocabulary != want.Vocabulary {
+		t.Errorf("Analyze = %+v, want words %d, sentences %d, vocabulary %d", s, want.Words, want.Sentences, want.Vocabulary)
+	}
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		text               string
+		words, sentences   int
+		letters, syllables int
+	}{
+		{"", 0, 0, 0, 0},
+		{"Don't stop.", 2, 1, 8, 2},
+		{"A well-known man—a sailor.", 5, 1, 20, 7},
+		{"Wait... what?! Fine.", 3, 3, 12, 3},
+		{"“Stop!” she said. “Go.”", 4, 3, 13, 4},
+		{"Pi is 3.14 exactly", 4, 1, 14, 6},
+		{"Born in 1,000 BC. Died in 1895. The end", 9, 3, 28, 9},
+	}
+	for _, tt := range tests {
+		s, err := Analyze(strings.NewReader(tt.text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Words != tt.words || s.Sentences != tt.sentences || s.Letters != tt.letters || s.Syllables != tt.syllables {
+			t.Errorf("%q: got words %d, sentences %d, letters %d, syllables %d; want %d, %d, %d, %d",
+				tt.text, s.Words, s.Sentences, s.Letters, s.Syllables, tt.words, tt.sentences, tt.letters, tt.syllables)
+		}
+	}
+}
+
+func TestSyllables(t *testing.T) {
+	tests := map[string]int{
+		"the": 1, "cat": 1, "table": 2, "jumped": 1, "wanted": 2, "makes": 1,
+		"horses": 2, "beautiful": 3, "education": 4, "university": 5,
+		"nation": 2, "usual": 3, "doing": 2, "rhythm": 1, "agree": 2,
+		"café": 2, "naïve": 2, "zoë": 2, "rôle": 1, "résumé": 3,
+	}
+	for w, want := range tests {
+		if got := syllables(w); got != want {
+			t.Errorf("syllables(%q) = %d, want %d", w, got, want)
+		}
+	}
+}
+
+func TestComplexWords(t *testing.T) {
+	s, err := Analyze(strings.NewReader("Beautiful educational opportunities. Abandoning Washington twenty-seventh everything."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "Beautiful" and "Washington" are capitalized, "twenty-seventh" is a
+	// compound, and "abandoning" has only two syllables without -ing.
+	if s.ComplexWords != 3 {
+		t.Errorf("ComplexWords = %d, want 3", s.ComplexWords)
+	}
+}
+
+func TestStreamingSplitsRunes(t *testing.T) {
+	text := strings.Repeat("Él lloró. Naïve café society—über alles! ", 50)
+	whole, err := Analyze(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	split, err := Analyze(iotest.OneByteReader(strings.NewReader(text)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if whole != split {
+		t.Errorf("byte-by-byte = %+v, whole = %+v", split, whole)
+	}
+	if whole.Words != 350 || whole.Sentences != 100 {
+		t.Errorf("Analyze = %+v", whole)
+	}
+}
+
+func TestStatsMidStream(t *testing.T) {
+	text := "It was the best of times. It was the worst of tim\u00e9s, it was the age of wisdom"
+	want, err := Analyze(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for cut := range len(text) + 1 {
+		a := NewAnalyzer()
+		_, _ = a.WriteString(text[:cut])
+		head := a.Stats()
+		if again := a.Stats(); again != head {
+			t.Fatalf("cut %d: Stats not repeatable: %+v, then %+v", cut, head, again)
+		}
+		_, _ = a.WriteString(text[cut:])
+		if got := a.Stats(); got != want {
+			t.Fatalf("cut %d: Stats after more writes = %+v, want %+v", cut, got, want)
+		}
+	}
+
+	a := NewAnalyzer()
+	_, _ = a.WriteString("It was the best of ti")
+	if got := a.Stats(); got.Words != 6 || got.Sentences != 1 || got.Vocabulary != 6 {
+		t.Errorf("Stats mid-word = %+v", got)
+	}
+}
+
+func TestScores(t *testing.T) {
+	s := Stats{Words: 100, Sentences: 5, Letters: 450, Syllables: 140, ComplexWords: 10, Vocabulary: 60}
+	approx := func(name string, got, want float64) {
+		t.Helper()
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+	approx("TypeTokenRatio", s.TypeTokenRatio(), 0.6)
+	approx("AvgWordLength", s.AvgWordLength(), 4.5)
+	approx("AvgSentenceLength", s.AvgSentenceLength(), 20)
+	approx("FleschKincaid", s.FleschKincaid(), 0.39*20+11.8*1.4-15.59)
+	approx("FleschReadingEase", s.FleschReadingEase(), 206.835-1.015*20-84.6*1.4)
+	approx("GunningFog", s.GunningFog(), 0.4*(20+10))
+	approx("ColemanLiau", s.ColemanLiau(), 0.0588*450-0.296*5-15.8)
+	if got := s.ReadingTime(200); got != 30*time.Second {
+		t.Errorf("ReadingTime(200) = %v", got)
+	}
+	if got := (Stats{Words: 238}).ReadingTime(0); got != time.Minute {
+		t.Errorf("ReadingTime(0) = %v", got)
+	}
+
+	var zero Stats
+	for name, v := range map[string]float64{
+		"TypeTokenRatio": zero.TypeTokenRatio(), "FleschKincaid": zero.FleschKincaid(),
+		"GunningFog": zero.GunningFog(), "ColemanLiau": zero.ColemanLiau(),
+	} {
+		if v != 0 {
+			t.Errorf("%s of empty text = %v", name, v)
+		}
+	}
+}
+
+func TestReadabilityOrdering(t *testing.T) {
+	easy, err := Analyze(strings.NewReader("The cat sat on the mat. It was a big cat. The dog ran to the cat. They sat."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hard, err := Analyze(strings.NewReader("Notwithstanding considerable institutional opposition, the administration implemented comprehensive regulatory modifications, substantially transforming organizational accountability mechanisms."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(easy.FleschKincaid() < hard.FleschKincaid() && easy.GunningFog() < hard.GunningFog() &&
+		easy.ColemanLiau() < hard.ColemanLiau() && easy.FleschReadingEase() > hard.FleschReadingEase()) {
+		t.Errorf("easy = %+v, hard = %+v", easy, hard)
+	}
+}
+
+func TestAnalyzeBook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, "The Project Gutenberg eBook\n*** START OF THE PROJECT GUTENBERG EBOOK ***\nCall me Ishmael.\n*** END OF THE PROJECT GUTENBERG EBOOK ***\nLicense terms follow here.\n")
+	}))
+	defer srv.Close()
+
+	b := gutendex.Book{ID: 2701, Formats: map[string]string{"text/plain; charset=utf-8": srv.URL + "/2701.txt"}}
+	s, err := AnalyzeBook(context.Background(), gutendex.NewClient(), b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Words != 3 || s.Sentences != 1 {
+		t.Errorf("AnalyzeBook = %+v", s)
+	}
+}
@@ -0,0 +1,135 @@
+package gutendex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type spanKey struct{}
+
+type recordedSpan struct {
+	name   string
+	id     int
+	parent int
+	attrs  map[string]any
+	err    error
+	ended  bool
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := &recordedSpan{name: name, id: len(t.spans) + 1, attrs: map[string]any{}}
+	if p, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+		s.parent = p.id
+	}
+	s.SetAttributes(attrs...)
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+func (s *recordedSpan) RecordError(err error) { s.err = err }
+func (s *recordedSpan) End()                  { s.ended = true }
+func (s *recordedSpan) TraceParent() string {
+	return fmt.Sprintf("00-0af7651916cd43dd8448eb211c80319c-%016x-01", s.id)
+}
+
+func (t *recordingTracer) byName(name string) []*recordedSpan {
+	var out []*recordedSpan
+	for _, s := range t.spans {
+		if s.name == name {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func TestTracingGetBook(t *testing.T) {
+	var traceparents []string
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"id":5}`)
+	}))
+	defer srv.Close()
+
+	tr := &recordingTracer{}
+	c := newTestClient(srv.URL)
+	WithTracer(tr)(c)
+	if _, err := c.GetBook(context.Background(), 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	op := tr.byName("gutendex.GetBook")
+	if len(op) != 1 || !op[0].ended || op[0].attrs["gutendex.book_id"] != 5 || op[0].attrs["http.status_code"] != 200 {
+		t.Fatalf("unexpected operation span: %+v", op)
+	}
+	tries := tr.byName("HTTP GET")
+	if len(tries) != 2 {
+		t.Fatalf("expected 2 attempt spans, got %d", len(tries))
+	}
+	for i, s := range tries {
+		if s.parent != op[0].id || s.attrs["http.attempt"] != i+1 || !s.ended {
+			t.Fatalf("attempt span %d: %+v", i, s)
+		}
+		if traceparents[i] != s.TraceParent() {
+			t.Fatalf("traceparent %q, want %q", traceparents[i], s.TraceParent())
+		}
+	}
+	if tries[0].attrs["http.status_code"] != 503 || tries[1].attrs["gutendex.cache"] != "miss" {
+		t.Fatalf("unexpected attempt attributes: %+v %+v", tries[0].attrs, tries[1].attrs)
+	}
+}
+
+func TestTracingListPages(t *testing.T) {
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			_, _ = fmt.Fprintf(w, `{"count":2,"next":"%s/books?page=2&topic=poetry","results":[{"id":1}]}`, srvURL)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	tr := &recordingTracer{}
+	c := newTestClient(srv.URL)
+	WithTracer(tr)(c)
+	it := c.ListBooks(Query{Topic: "poetry"})
+	for it.Next() {
+	}
+	if !IsNotFound(it.Err()) {
+		t.Fatalf("expected not found, got %v", it.Err())
+	}
+
+	pages := tr.byName("gutendex.ListBooks.page")
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 page spans, got %d", len(pages))
+	}
+	if pages[0].attrs["gutendex.page"] != 1 || pages[0].attrs["gutendex.query.topic"] != "poetry" {
+		t.Fatalf("unexpected first page attributes: %+v", pages[0].attrs)
+	}
+	if pages[1].attrs["gutendex.page"] != 2 || pages[1].err == nil || pages[1].attrs["http.status_code"] != 404 {
+		t.Fatalf("unexpected second page span: %+v", pages[1])
+	}
+}